@@ -0,0 +1,58 @@
+package fibererror
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"strings"
+)
+
+type allow []string
+
+// WithAllow wraps a 405 error with the methods written as its Allow header,
+// instead of those registered for the path of the request:
+//
+//	response.With(c).Response(fibererror.WithAllow(goerror.NewMethodNotAllowed(), fiber.MethodGet, fiber.MethodHead))
+//	// 405 Allow: GET, HEAD
+func WithAllow(err error, methods ...string) error {
+	return annotate(err, allow(methods))
+}
+
+// allow writes the Allow header of a 405 response from the first methods in
+// the chain of err, or the methods of the routes matching the request path.
+func (s *httpResponse) allow(status int, err error) {
+	if status != fiber.StatusMethodNotAllowed {
+		return
+	}
+	methods, ok := lookup(err, func(v any) ([]string, bool) {
+		switch t := v.(type) {
+		case allow:
+			return t, true
+		case interface{ Allow() []string }:
+			return t.Allow(), true
+		}
+		return nil, false
+	})
+	if !ok {
+		methods = s.allowedMethods()
+	}
+	if len(methods) > 0 {
+		s.Ctx.Set(fiber.HeaderAllow, strings.Join(methods, ", "))
+	}
+}
+
+// allowedMethods returns the methods of the routes matching the request
+// path, other than the refused method of the request. Middleware, which Fiber
+// registers for every method, is skipped.
+func (s *httpResponse) allowedMethods() []string {
+	app := s.Ctx.App()
+	cfg := app.Config()
+	path := s.Ctx.Path()
+	seen := map[string]bool{s.Ctx.Method(): true}
+	var methods []string
+	for _, r := range app.GetRoutes(true) {
+		if !seen[r.Method] && fiber.RoutePatternMatch(path, r.Path, cfg) {
+			seen[r.Method] = true
+			methods = append(methods, r.Method)
+		}
+	}
+	return methods
+}
@@ -0,0 +1,99 @@
+package fibererror_test
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/prongbang/fibererror"
+	"github.com/prongbang/goerror"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAllow(t *testing.T) {
+	app := fiber.New()
+	res := fibererror.New()
+	app.Get("/users/:id", func(c *fiber.Ctx) error {
+		return c.SendStatus(http.StatusOK)
+	})
+	app.Delete("/users/:id", func(c *fiber.Ctx) error {
+		return res.With(c).Response(goerror.NewMethodNotAllowed())
+	})
+
+	resp, _ := app.Test(httptest.NewRequest("DELETE", "/users/42", nil))
+
+	if resp.StatusCode != http.StatusMethodNotAllowed || resp.Header.Get(fiber.HeaderAllow) != "GET, HEAD" {
+		t.Error("Error", resp.StatusCode, resp.Header)
+	}
+}
+
+func TestAllowErrorHandler(t *testing.T) {
+	app := fiber.New(fiber.Config{
+		ErrorHandler: fibererror.ErrorHandler(&fibererror.Config{}),
+	})
+	app.Get("/users/:id", func(c *fiber.Ctx) error {
+		return c.SendStatus(http.StatusOK)
+	})
+	app.Put("/users/:id", func(c *fiber.Ctx) error {
+		return c.SendStatus(http.StatusOK)
+	})
+	app.Get("/orders", func(c *fiber.Ctx) error {
+		return c.SendStatus(http.StatusOK)
+	})
+
+	resp, _ := app.Test(httptest.NewRequest("POST", "/users/42", nil))
+
+	if resp.StatusCode != http.StatusMethodNotAllowed || resp.Header.Get(fiber.HeaderAllow) != "GET, HEAD, PUT" {
+		t.Error("Error", resp.StatusCode, resp.Header)
+	}
+}
+
+func TestAllowMiddleware(t *testing.T) {
+	cfg := &fibererror.Config{}
+	app := fiber.New(fiber.Config{
+		ErrorHandler: fibererror.ErrorHandler(cfg),
+	})
+	app.Use(fibererror.Recover(cfg))
+	users := app.Group("/users", fibererror.WithCustom())
+	users.Get("/", func(c *fiber.Ctx) error {
+		return c.SendStatus(http.StatusOK)
+	})
+	app.Get("/", func(c *fiber.Ctx) error {
+		return c.SendStatus(http.StatusOK)
+	})
+
+	for _, path := range []string{"/", "/users"} {
+		resp, _ := app.Test(httptest.NewRequest("POST", path, nil))
+
+		if resp.StatusCode != http.StatusMethodNotAllowed || resp.Header.Get(fiber.HeaderAllow) != "GET, HEAD" {
+			t.Error("Error", path, resp.StatusCode, resp.Header)
+		}
+	}
+}
+
+func TestWithAllow(t *testing.T) {
+	app := fiber.New()
+	res := fibererror.New()
+	app.Post("/reports", func(c *fiber.Ctx) error {
+		return res.With(c).Response(fibererror.WithAllow(goerror.NewMethodNotAllowed(), fiber.MethodGet))
+	})
+
+	resp, _ := app.Test(httptest.NewRequest("POST", "/reports", nil))
+
+	if resp.StatusCode != http.StatusMethodNotAllowed || resp.Header.Get(fiber.HeaderAllow) != "GET" {
+		t.Error("Error", resp.StatusCode, resp.Header)
+	}
+}
+
+func TestAllowOtherStatus(t *testing.T) {
+	app := fiber.New()
+	res := fibererror.New()
+	app.Get("/test", func(c *fiber.Ctx) error {
+		return res.With(c).Response(goerror.NewNotFound())
+	})
+
+	resp, _ := app.Test(httptest.NewRequest("GET", "/test", nil))
+
+	if resp.Header.Get(fiber.HeaderAllow) != "" {
+		t.Error("Error", resp.Header)
+	}
+}
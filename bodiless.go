@@ -0,0 +1,29 @@
+package fibererror
+
+import "github.com/gofiber/fiber/v2"
+
+// bodiless reports whether a response with status must not carry a body:
+// 1xx, 204 No Content, 205 Reset Content, 304 Not Modified, and any response
+// to a HEAD request.
+func (s *httpResponse) bodiless(status int) bool {
+	switch {
+	case status < fiber.StatusOK,
+		status == fiber.StatusNoContent,
+		status == fiber.StatusResetContent,
+		status == fiber.StatusNotModified:
+		return true
+	}
+	return s.Ctx.Method() == fiber.MethodHead
+}
+
+// strip writes status without a body and its content headers. Other headers,
+// such as the ETag and Cache-Control of a 304, are kept.
+func (s *httpResponse) strip(status int) {
+	res := s.Ctx.Status(status).Response()
+	res.ResetBody()
+	res.Header.SetNoDefaultContentType(true)
+	res.Header.Del(fiber.HeaderContentType)
+	res.Header.Del(fiber.HeaderContentLength)
+	res.Header.Del(fiber.HeaderContentEncoding)
+	res.Header.Del(fiber.HeaderContentLanguage)
+}
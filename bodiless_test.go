@@ -0,0 +1,68 @@
+package fibererror_test
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/prongbang/goerror"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestBodiless(t *testing.T) {
+	errs := map[int]error{
+		http.StatusContinue:           goerror.NewContinue(),
+		http.StatusSwitchingProtocols: goerror.NewSwitchingProtocols(),
+		http.StatusProcessing:         goerror.NewProcessing(),
+		http.StatusEarlyHints:         goerror.NewEarlyHints(),
+		http.StatusNoContent:          goerror.NewNoContent(),
+		http.StatusResetContent:       goerror.NewResetContent(),
+		http.StatusNotModified:        goerror.NewNotModified(),
+	}
+	for status, err := range errs {
+		resp, body := respond(t, response, err, nil)
+
+		if resp.StatusCode != status || body != "" || resp.Header.Get(fiber.HeaderContentType) != "" {
+			t.Error("Error", status, resp.StatusCode, resp.Header, body)
+		}
+	}
+}
+
+func TestBodilessNotModifiedKeepsHeaders(t *testing.T) {
+	etag := func(c *fiber.Ctx) error {
+		c.Set(fiber.HeaderETag, `"v1"`)
+		return c.Next()
+	}
+	resp, _ := respond(t, response, goerror.NewNotModified(), nil, etag)
+
+	if resp.Header.Get(fiber.HeaderETag) != `"v1"` {
+		t.Error("Error", resp.Header)
+	}
+}
+
+func TestBodilessHead(t *testing.T) {
+	resp, body := respond(t, response, goerror.NewNotFound(), httptest.NewRequest(fiber.MethodHead, "/test", nil))
+
+	if resp.StatusCode != http.StatusNotFound || body != "" || resp.Header.Get(fiber.HeaderContentType) != "" {
+		t.Error("Error", resp.StatusCode, resp.Header, body)
+	}
+}
+
+func TestBody(t *testing.T) {
+	errs := map[int]error{
+		http.StatusOK:                   goerror.NewOK(nil),
+		http.StatusCreated:              goerror.NewCreated(nil),
+		http.StatusAccepted:             goerror.NewAccepted(),
+		http.StatusNonAuthoritativeInfo: goerror.NewNonAuthoritativeInformation(),
+		http.StatusPartialContent:       goerror.NewPartialContent(),
+		http.StatusMultiStatus:          goerror.NewMultiStatus(),
+		http.StatusAlreadyReported:      goerror.NewAlreadyReported(),
+		http.StatusIMUsed:               goerror.NewIMUsed(),
+	}
+	for status, err := range errs {
+		resp, body := respond(t, response, err, nil)
+
+		if resp.StatusCode != status || body == "" || resp.Header.Get(fiber.HeaderContentType) != fiber.MIMEApplicationJSON {
+			t.Error("Error", status, resp.StatusCode, resp.Header, body)
+		}
+	}
+}
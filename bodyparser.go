@@ -0,0 +1,100 @@
+package fibererror
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"github.com/gofiber/fiber/v2"
+	"github.com/prongbang/goerror"
+	"net/http"
+)
+
+// Reasons of a ParseError.
+const (
+	ReasonMalformedJSON        = "malformed_json"
+	ReasonMalformedXML         = "malformed_xml"
+	ReasonInvalidType          = "invalid_type"
+	ReasonUnsupportedMediaType = "unsupported_media_type"
+)
+
+// ParseError is the Data of the error written for a request body that
+// BodyParser could not parse.
+type ParseError struct {
+	// Reason is one of the Reason constants.
+	Reason string `json:"reason"`
+	// Field is the path of the field with an invalid value, e.g. "address.city".
+	Field string `json:"field,omitempty"`
+	// Offset is the byte offset in the body the error was found at.
+	Offset int64 `json:"offset,omitempty"`
+	// Line is the line of a malformed XML body.
+	Line int `json:"line,omitempty"`
+	// Value is the kind of the invalid value, e.g. "string".
+	Value string `json:"value,omitempty"`
+	// Expected is the type the value was decoded into, e.g. "int".
+	Expected string `json:"expected,omitempty"`
+}
+
+// parsing marks the errors returned by BodyParser.
+type parsing struct{}
+
+// BodyParser parses the request body into out with Ctx.BodyParser. Its
+// errors are written with a ParseError as Data:
+//
+//	if err := fibererror.BodyParser(c, &profile); err != nil {
+//		return response.With(c).Response(err)
+//	}
+func BodyParser(c *fiber.Ctx, out any) error {
+	if err := c.BodyParser(out); err != nil {
+		return annotate(err, parsing{})
+	}
+	return nil
+}
+
+// translateBodyParser is the default Translator for the errors returned by
+// BodyParser. Malformed bodies and values of the wrong type become a
+// BadRequest, and fiber.ErrUnprocessableEntity, which Ctx.BodyParser returns
+// for a Content-Type it cannot parse, an UnsupportedMediaType, each with a
+// ParseError as Data. The same errors from anywhere else, such as decoding
+// the response of another service, are not translated.
+func translateBodyParser(err error) error {
+	a, ok := err.(*annotation)
+	if !ok || a.value != (parsing{}) {
+		return nil
+	}
+	var (
+		syntaxErr *json.SyntaxError
+		typeErr   *json.UnmarshalTypeError
+		xmlErr    *xml.SyntaxError
+	)
+	switch {
+	case errors.As(a.error, &syntaxErr):
+		return badRequest(ParseError{Reason: ReasonMalformedJSON, Offset: syntaxErr.Offset})
+	case errors.As(a.error, &typeErr):
+		p := ParseError{Reason: ReasonInvalidType, Field: typeErr.Field, Offset: typeErr.Offset, Value: typeErr.Value}
+		if typeErr.Type != nil {
+			p.Expected = typeErr.Type.String()
+		}
+		return badRequest(p)
+	case errors.As(a.error, &xmlErr):
+		return badRequest(ParseError{Reason: ReasonMalformedXML, Line: xmlErr.Line})
+	case errors.Is(a.error, fiber.ErrUnprocessableEntity):
+		return &goerror.UnsupportedMediaType{
+			Body: goerror.Body{
+				Code:    goerror.CodeUnsupportedMediaType,
+				Message: http.StatusText(http.StatusUnsupportedMediaType),
+				Data:    ParseError{Reason: ReasonUnsupportedMediaType},
+			},
+		}
+	}
+	return nil
+}
+
+func badRequest(p ParseError) error {
+	return &goerror.BadRequest{
+		Body: goerror.Body{
+			Code:    goerror.CodeBadRequest,
+			Message: http.StatusText(http.StatusBadRequest),
+			Data:    p,
+		},
+	}
+}
@@ -0,0 +1,122 @@
+package fibererror_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/gofiber/fiber/v2"
+	"github.com/prongbang/fibererror"
+	"github.com/prongbang/goerror"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type Profile struct {
+	Name    string `json:"name" xml:"name"`
+	Address struct {
+		Zip int `json:"zip" xml:"zip"`
+	} `json:"address" xml:"address"`
+}
+
+// createProfile writes the error of parsing the body as a Profile.
+func createProfile(c *fiber.Ctx) error {
+	var profile Profile
+	if err := fibererror.BodyParser(c, &profile); err != nil {
+		return response.With(c).Response(err)
+	}
+	return c.SendStatus(http.StatusOK)
+}
+
+func TestBodyParserMalformedJSON(t *testing.T) {
+	app := fiber.New()
+	app.Post("/test", createProfile)
+
+	req := httptest.NewRequest("POST", "/test", strings.NewReader(`{"name":"john",}`))
+	req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+	resp, _ := app.Test(req)
+	body, _ := io.ReadAll(resp.Body)
+
+	expected := `{"code":"CLE000","message":"Bad Request","data":{"reason":"malformed_json","offset":16}}`
+	if resp.StatusCode != http.StatusBadRequest || string(body) != expected {
+		t.Error("Error", resp.StatusCode, string(body))
+	}
+}
+
+func TestBodyParserInvalidType(t *testing.T) {
+	app := fiber.New()
+	app.Post("/test", createProfile)
+
+	req := httptest.NewRequest("POST", "/test", strings.NewReader(`{"name":"john","address":{"zip":"10110"}}`))
+	req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+	resp, _ := app.Test(req)
+	body, _ := io.ReadAll(resp.Body)
+
+	expected := `{"code":"CLE000","message":"Bad Request","data":{"reason":"invalid_type","field":"address.zip","offset":39,"value":"string","expected":"int"}}`
+	if resp.StatusCode != http.StatusBadRequest || string(body) != expected {
+		t.Error("Error", resp.StatusCode, string(body))
+	}
+}
+
+func TestBodyParserMalformedXML(t *testing.T) {
+	app := fiber.New()
+	app.Post("/test", createProfile)
+
+	req := httptest.NewRequest("POST", "/test", strings.NewReader("<profile>\n<name>john</profile>"))
+	req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationXML)
+	resp, _ := app.Test(req)
+	body, _ := io.ReadAll(resp.Body)
+
+	expected := `{"code":"CLE000","message":"Bad Request","data":{"reason":"malformed_xml","line":2}}`
+	if resp.StatusCode != http.StatusBadRequest || string(body) != expected {
+		t.Error("Error", resp.StatusCode, string(body))
+	}
+}
+
+func TestBodyParserUnsupportedMediaType(t *testing.T) {
+	app := fiber.New()
+	app.Post("/test", createProfile)
+
+	req := httptest.NewRequest("POST", "/test", strings.NewReader("data"))
+	req.Header.Set(fiber.HeaderContentType, "application/octet-stream")
+	resp, _ := app.Test(req)
+	body, _ := io.ReadAll(resp.Body)
+
+	expected := `{"code":"CLE015","message":"Unsupported Media Type","data":{"reason":"unsupported_media_type"}}`
+	if resp.StatusCode != http.StatusUnsupportedMediaType || string(body) != expected {
+		t.Error("Error", resp.StatusCode, string(body))
+	}
+}
+
+func TestFiberErrorUnprocessableEntity(t *testing.T) {
+	app := fiber.New()
+	app.Get("/test", func(c *fiber.Ctx) error {
+		return response.With(c).Response(fiber.NewError(fiber.StatusUnprocessableEntity))
+	})
+
+	resp, _ := app.Test(httptest.NewRequest("GET", "/test", nil))
+
+	if resp.StatusCode != http.StatusUnprocessableEntity {
+		t.Error("Error", resp.StatusCode)
+	}
+}
+
+func TestFiberErrorUnprocessableEntitySentinel(t *testing.T) {
+	resp, body := respond(t, response, fiber.ErrUnprocessableEntity, nil)
+
+	if resp.StatusCode != http.StatusUnprocessableEntity || !strings.Contains(body, goerror.CodeUnprocessableEntity) {
+		t.Error("Error", resp.StatusCode, body)
+	}
+}
+
+func TestBodyParserOtherJSONError(t *testing.T) {
+	var v struct{ N int }
+	jsonErr := json.Unmarshal([]byte(`{"N":"1"}`), &v)
+
+	resp, body := respond(t, response, fmt.Errorf("decode billing service response: %w", jsonErr), nil)
+
+	if resp.StatusCode != http.StatusInternalServerError || strings.Contains(body, "invalid_type") {
+		t.Error("Error", resp.StatusCode, body)
+	}
+}
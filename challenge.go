@@ -0,0 +1,138 @@
+package fibererror
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"sort"
+	"strings"
+)
+
+// Bearer token errors of RFC 6750, for Challenge.Error.
+const (
+	ChallengeInvalidRequest    = "invalid_request"
+	ChallengeInvalidToken      = "invalid_token"
+	ChallengeInsufficientScope = "insufficient_scope"
+)
+
+// Challenge is the authentication challenge written as the WWW-Authenticate
+// header of a 401 response, or the Proxy-Authenticate header of a 407
+// response.
+type Challenge struct {
+	// Scheme defaults to "Bearer".
+	Scheme string
+	Realm  string
+	Scope  string
+	// Error and ErrorDescription are the RFC 6750 error parameters, e.g.
+	// ChallengeInvalidToken.
+	Error            string
+	ErrorDescription string
+	// Params are other auth parameters, written in name order.
+	Params map[string]string
+}
+
+// String returns the challenge as a header value, e.g.
+// Bearer realm="api", error="invalid_token".
+func (c *Challenge) String() string {
+	scheme := c.Scheme
+	if scheme == "" {
+		scheme = "Bearer"
+	}
+	var params []string
+	add := func(name, value string) {
+		if value != "" {
+			params = append(params, name+"="+quote(value))
+		}
+	}
+	add("realm", c.Realm)
+	add("scope", c.Scope)
+	add("error", c.Error)
+	add("error_description", c.ErrorDescription)
+	names := make([]string, 0, len(c.Params))
+	for name := range c.Params {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		add(name, c.Params[name])
+	}
+	if len(params) == 0 {
+		return scheme
+	}
+	return scheme + " " + strings.Join(params, ", ")
+}
+
+// merge returns c with the fields set in o replacing its own.
+func (c Challenge) merge(o Challenge) Challenge {
+	if o.Scheme != "" {
+		c.Scheme = o.Scheme
+	}
+	if o.Realm != "" {
+		c.Realm = o.Realm
+	}
+	if o.Scope != "" {
+		c.Scope = o.Scope
+	}
+	if o.Error != "" {
+		c.Error = o.Error
+	}
+	if o.ErrorDescription != "" {
+		c.ErrorDescription = o.ErrorDescription
+	}
+	if len(o.Params) > 0 {
+		params := make(map[string]string, len(c.Params)+len(o.Params))
+		for k, v := range c.Params {
+			params[k] = v
+		}
+		for k, v := range o.Params {
+			params[k] = v
+		}
+		c.Params = params
+	}
+	return c
+}
+
+// quote returns s as an HTTP quoted-string.
+func quote(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
+
+// WithChallenge wraps err with the authentication challenge of its 401 or
+// 407 response. Its fields replace those of Config.Challenge, so the scheme
+// and realm can be configured once:
+//
+//	response.With(c).Response(fibererror.WithChallenge(goerror.NewUnauthorized(), fibererror.Challenge{
+//		Error:            fibererror.ChallengeInvalidToken,
+//		ErrorDescription: "The access token expired",
+//	}))
+//	// WWW-Authenticate: Bearer realm="api", error="invalid_token", error_description="The access token expired"
+func WithChallenge(err error, challenge Challenge) error {
+	return annotate(err, challenge)
+}
+
+// challenge writes the authentication challenge header of a 401 or 407
+// response, from Config.Challenge and the first challenge in the chain of err.
+func (s *httpResponse) challenge(status int, err error) {
+	var header string
+	switch status {
+	case fiber.StatusUnauthorized:
+		header = fiber.HeaderWWWAuthenticate
+	case fiber.StatusProxyAuthRequired:
+		header = fiber.HeaderProxyAuthenticate
+	default:
+		return
+	}
+	c, found := lookup(err, func(v any) (Challenge, bool) {
+		switch t := v.(type) {
+		case Challenge:
+			return t, true
+		case interface{ Challenge() Challenge }:
+			return t.Challenge(), true
+		}
+		return Challenge{}, false
+	})
+	if s.Challenge != nil {
+		c, found = s.Challenge.merge(c), true
+	}
+	if found {
+		s.Ctx.Set(header, c.String())
+	}
+}
@@ -0,0 +1,95 @@
+package fibererror_test
+
+import (
+	"errors"
+	"fmt"
+	"github.com/gofiber/fiber/v2"
+	"github.com/prongbang/fibererror"
+	"github.com/prongbang/goerror"
+	"net/http"
+	"testing"
+)
+
+func TestChallenge(t *testing.T) {
+	resp, _ := respond(t, fibererror.New(&fibererror.Config{
+		Challenge: &fibererror.Challenge{Realm: "api"},
+	}), goerror.NewUnauthorized(), nil)
+
+	if resp.StatusCode != http.StatusUnauthorized || resp.Header.Get(fiber.HeaderWWWAuthenticate) != `Bearer realm="api"` {
+		t.Error("Error", resp.StatusCode, resp.Header)
+	}
+}
+
+func TestWithChallenge(t *testing.T) {
+	err := fmt.Errorf("auth: %w", fibererror.WithChallenge(goerror.NewUnauthorized(), fibererror.Challenge{
+		Error:            fibererror.ChallengeInvalidToken,
+		ErrorDescription: `The "access" token expired`,
+	}))
+	resp, _ := respond(t, fibererror.New(&fibererror.Config{
+		Challenge: &fibererror.Challenge{Realm: "api"},
+	}), err, nil)
+
+	expected := `Bearer realm="api", error="invalid_token", error_description="The \"access\" token expired"`
+	if resp.Header.Get(fiber.HeaderWWWAuthenticate) != expected {
+		t.Error("Error", resp.Header.Get(fiber.HeaderWWWAuthenticate))
+	}
+}
+
+func TestChallengeProxyAuthRequired(t *testing.T) {
+	resp, _ := respond(t, fibererror.New(&fibererror.Config{
+		Challenge: &fibererror.Challenge{
+			Scheme: "Basic",
+			Realm:  "proxy",
+			Params: map[string]string{"charset": "UTF-8"},
+		},
+	}), goerror.NewProxyAuthRequired(), nil)
+
+	if resp.StatusCode != http.StatusProxyAuthRequired ||
+		resp.Header.Get(fiber.HeaderProxyAuthenticate) != `Basic realm="proxy", charset="UTF-8"` ||
+		resp.Header.Get(fiber.HeaderWWWAuthenticate) != "" {
+		t.Error("Error", resp.StatusCode, resp.Header)
+	}
+}
+
+func TestChallengeOtherStatus(t *testing.T) {
+	resp, _ := respond(t, fibererror.New(&fibererror.Config{
+		Challenge: &fibererror.Challenge{Realm: "api"},
+	}), goerror.NewForbidden(), nil)
+
+	if resp.Header.Get(fiber.HeaderWWWAuthenticate) != "" {
+		t.Error("Error", resp.Header)
+	}
+}
+
+func TestChallengeNotConfigured(t *testing.T) {
+	resp, _ := respond(t, response, goerror.NewUnauthorized(), nil)
+
+	if resp.Header.Get(fiber.HeaderWWWAuthenticate) != "" {
+		t.Error("Error", resp.Header)
+	}
+}
+
+type authResponse struct{}
+
+// Response implements fibererror.Custom.
+func (authResponse) Response(ctx *fiber.Ctx, err error) error {
+	return ctx.Status(http.StatusUnauthorized).JSON(fiber.Map{"error": "login required"})
+}
+
+func TestChallengeCustom(t *testing.T) {
+	var custom fibererror.Custom = authResponse{}
+	res := fibererror.New(&fibererror.Config{
+		Custom:    &custom,
+		Challenge: &fibererror.Challenge{Realm: "api"},
+	})
+	err := fibererror.WithChallenge(errors.New("no session"), fibererror.Challenge{
+		Error: fibererror.ChallengeInvalidToken,
+	})
+
+	resp, _ := respond(t, res, err, nil)
+
+	if resp.StatusCode != http.StatusUnauthorized ||
+		resp.Header.Get(fiber.HeaderWWWAuthenticate) != `Bearer realm="api", error="invalid_token"` {
+		t.Error("Error", resp.StatusCode, resp.Header)
+	}
+}
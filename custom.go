@@ -0,0 +1,35 @@
+package fibererror
+
+import (
+	"github.com/gofiber/fiber/v2"
+)
+
+type localsKey int
+
+const customsKey localsKey = iota
+
+// WithCustom returns a middleware that attaches Custom handlers to the routes
+// it is mounted on, so each route group can contribute its own mappings:
+//
+//	billing := app.Group("/billing", fibererror.WithCustom(billingResp))
+//
+// Handlers of a group are tried before those of its parent groups, and all of
+// them before the handlers of Config.
+func WithCustom(customs ...Custom) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		scoped, _ := c.Locals(customsKey).([]Custom)
+		c.Locals(customsKey, append(append([]Custom{}, customs...), scoped...))
+		return c.Next()
+	}
+}
+
+// customs returns the Custom handlers in the order they are tried.
+func (s *httpResponse) customs() []Custom {
+	scoped, _ := s.Ctx.Locals(customsKey).([]Custom)
+	customs := make([]Custom, 0, len(scoped)+len(s.Customs)+1)
+	customs = append(customs, scoped...)
+	if s.Cus != nil {
+		customs = append(customs, *s.Cus)
+	}
+	return append(customs, s.Customs...)
+}
@@ -0,0 +1,105 @@
+package fibererror_test
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/prongbang/fibererror"
+	"github.com/prongbang/goerror"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type InvoiceError struct {
+	goerror.Body
+}
+
+// Error implements error.
+func (i *InvoiceError) Error() string {
+	return i.Message
+}
+
+type IdentityError struct {
+	goerror.Body
+}
+
+// Error implements error.
+func (i *IdentityError) Error() string {
+	return i.Message
+}
+
+type billingResponse struct {
+}
+
+// Response implements response.Custom.
+func (b *billingResponse) Response(ctx *fiber.Ctx, err error) error {
+	switch e := err.(type) {
+	case *InvoiceError:
+		return ctx.Status(http.StatusPaymentRequired).JSON(e)
+	}
+	return fibererror.ErrUnhandled
+}
+
+type identityResponse struct {
+}
+
+// Response implements response.Custom.
+func (i *identityResponse) Response(ctx *fiber.Ctx, err error) error {
+	switch e := err.(type) {
+	case *IdentityError:
+		return ctx.Status(http.StatusForbidden).JSON(e)
+	case *InvoiceError:
+		return ctx.Status(http.StatusConflict).JSON(e)
+	}
+	return fibererror.ErrUnhandled
+}
+
+func TestCustoms(t *testing.T) {
+	app := fiber.New()
+
+	res := fibererror.New(&fibererror.Config{
+		Customs: []fibererror.Custom{&billingResponse{}, &identityResponse{}},
+	})
+
+	app.Get("/invoice", func(c *fiber.Ctx) error {
+		return res.With(c).Response(&InvoiceError{})
+	})
+	app.Get("/identity", func(c *fiber.Ctx) error {
+		return res.With(c).Response(&IdentityError{})
+	})
+
+	resp, _ := app.Test(httptest.NewRequest("GET", "/invoice", nil))
+	if resp.StatusCode != http.StatusPaymentRequired {
+		t.Error("Error", resp.StatusCode)
+	}
+
+	resp, _ = app.Test(httptest.NewRequest("GET", "/identity", nil))
+	if resp.StatusCode != http.StatusForbidden {
+		t.Error("Error", resp.StatusCode)
+	}
+}
+
+func TestWithCustom(t *testing.T) {
+	app := fiber.New(fiber.Config{
+		ErrorHandler: fibererror.ErrorHandler(&fibererror.Config{
+			Customs: []fibererror.Custom{&identityResponse{}},
+		}),
+	})
+
+	billing := app.Group("/billing", fibererror.WithCustom(&billingResponse{}))
+	billing.Get("/invoice", func(c *fiber.Ctx) error {
+		return &InvoiceError{}
+	})
+	app.Get("/invoice", func(c *fiber.Ctx) error {
+		return &InvoiceError{}
+	})
+
+	resp, _ := app.Test(httptest.NewRequest("GET", "/billing/invoice", nil))
+	if resp.StatusCode != http.StatusPaymentRequired {
+		t.Error("Error", resp.StatusCode)
+	}
+
+	resp, _ = app.Test(httptest.NewRequest("GET", "/invoice", nil))
+	if resp.StatusCode != http.StatusConflict {
+		t.Error("Error", resp.StatusCode)
+	}
+}
@@ -0,0 +1,56 @@
+package fibererror
+
+import (
+	"fmt"
+	"runtime/debug"
+	"strings"
+)
+
+// Debug is the debug member written in Debug mode.
+type Debug struct {
+	Chain []DebugLink `json:"chain"`
+	Stack []string    `json:"stack,omitempty"`
+}
+
+// DebugLink is one error in the chain of the error given to Response.
+type DebugLink struct {
+	Type    string `json:"type"`
+	Message string `json:"message"`
+}
+
+type stackTrace []byte
+
+// WithStack wraps err with the stack trace of its caller, written in Debug
+// mode:
+//
+//	return fibererror.WithStack(goerror.NewInternalServerError())
+func WithStack(err error) error {
+	return annotate(err, stackTrace(debug.Stack()))
+}
+
+// debugOf returns the chain of err with the stack trace captured by WithStack
+// or of the panic that caused it. Without one, the stack is omitted, as the
+// stack of Response would not show where err happened.
+func debugOf(err error) *Debug {
+	links := chain(err)
+	d := &Debug{Chain: make([]DebugLink, len(links))}
+	for i, e := range links {
+		d.Chain[i] = DebugLink{Type: fmt.Sprintf("%T", e), Message: e.Error()}
+	}
+	stack, _ := lookup(err, func(v any) ([]byte, bool) {
+		switch t := v.(type) {
+		case stackTrace:
+			return t, true
+		case *PanicError:
+			return t.Stack, true
+		}
+		return nil, false
+	})
+	if len(stack) == 0 {
+		return d
+	}
+	for _, line := range strings.Split(strings.TrimSpace(string(stack)), "\n") {
+		d.Stack = append(d.Stack, strings.TrimSpace(line))
+	}
+	return d
+}
@@ -0,0 +1,108 @@
+package fibererror_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/gofiber/fiber/v2"
+	"github.com/prongbang/fibererror"
+	"github.com/prongbang/goerror"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type debugBody struct {
+	Code  string            `json:"code"`
+	Debug *fibererror.Debug `json:"debug"`
+}
+
+func TestDebug(t *testing.T) {
+	res := fibererror.New(&fibererror.Config{Debug: true})
+
+	_, data := respond(t, res, fmt.Errorf("load user: %w", goerror.NewNotFound()), nil)
+	var body debugBody
+	_ = json.Unmarshal([]byte(data), &body)
+
+	if body.Code != goerror.CodeNotFound || body.Debug == nil || len(body.Debug.Chain) != 2 ||
+		body.Debug.Chain[0].Type != "*fmt.wrapError" || body.Debug.Chain[0].Message != "load user: Not Found" ||
+		body.Debug.Chain[1].Type != "*goerror.NotFound" || body.Debug.Stack != nil {
+		t.Error("Error", body, body.Debug)
+	}
+}
+
+func TestDebugWithStack(t *testing.T) {
+	res := fibererror.New(&fibererror.Config{Debug: true})
+
+	_, data := respond(t, res, fibererror.WithStack(goerror.NewNotFound()), nil)
+	var body debugBody
+	_ = json.Unmarshal([]byte(data), &body)
+
+	if body.Debug == nil || !strings.Contains(strings.Join(body.Debug.Stack, "\n"), "debug_test.go") {
+		t.Error("Error", body.Debug)
+	}
+}
+
+func TestDebugCustom(t *testing.T) {
+	customResp := NewCustomResponse()
+	res := fibererror.New(&fibererror.Config{
+		Debug:  true,
+		Custom: &customResp,
+	})
+
+	_, data := respond(t, res, fmt.Errorf("load: %w", &CustomError{Body: goerror.Body{Code: "CUS001", Message: "Custom"}}), nil)
+	var body debugBody
+	_ = json.Unmarshal([]byte(data), &body)
+
+	if body.Code != "CUS001" || body.Debug == nil || len(body.Debug.Chain) != 2 {
+		t.Error("Error", body, body.Debug)
+	}
+}
+
+func TestDebugPanicStack(t *testing.T) {
+	app := fiber.New()
+	app.Use(fibererror.Recover(&fibererror.Config{Debug: true}))
+	app.Get("/test", func(c *fiber.Ctx) error {
+		panic("boom")
+	})
+
+	resp, _ := app.Test(httptest.NewRequest("GET", "/test", nil))
+	var body debugBody
+	_ = json.NewDecoder(resp.Body).Decode(&body)
+
+	if body.Debug == nil || body.Debug.Chain[0].Type != "*fibererror.PanicError" ||
+		!strings.Contains(strings.Join(body.Debug.Stack, "\n"), "debug_test.go") {
+		t.Error("Error", body.Debug)
+	}
+}
+
+func TestDebugDisabled(t *testing.T) {
+	app := fiber.New()
+	app.Use(fibererror.Recover(&fibererror.Config{}))
+	app.Get("/test", func(c *fiber.Ctx) error {
+		panic("boom")
+	})
+
+	resp, _ := app.Test(httptest.NewRequest("GET", "/test", nil))
+	var body debugBody
+	_ = json.NewDecoder(resp.Body).Decode(&body)
+
+	if body.Code != goerror.CodeInternalServerError || body.Debug != nil {
+		t.Error("Error", body, body.Debug)
+	}
+}
+
+func TestDebugProblem(t *testing.T) {
+	app := fiber.New()
+	app.Use(fibererror.Recover(&fibererror.Config{Debug: true, Format: fibererror.FormatProblem}))
+	app.Get("/test", func(c *fiber.Ctx) error {
+		panic("boom")
+	})
+
+	resp, _ := app.Test(httptest.NewRequest("GET", "/test", nil))
+	var body debugBody
+	_ = json.NewDecoder(resp.Body).Decode(&body)
+
+	if body.Code != goerror.CodeInternalServerError || body.Debug == nil {
+		t.Error("Error", body, body.Debug)
+	}
+}
@@ -0,0 +1,21 @@
+package fibererror
+
+import (
+	"github.com/gofiber/fiber/v2"
+)
+
+// ErrorHandler returns a fiber.ErrorHandler that writes every error returned
+// by a handler through fibererror, so handlers can simply return err:
+//
+//	app := fiber.New(fiber.Config{
+//		ErrorHandler: fibererror.ErrorHandler(&fibererror.Config{}),
+//	})
+//
+// A *fiber.Error raised by Fiber itself, such as 404 for an unknown route or
+// 413 for a body over the limit, is written as the goerror type of its code.
+func ErrorHandler(cfg *Config) fiber.ErrorHandler {
+	resp := New(cfg)
+	return func(c *fiber.Ctx, err error) error {
+		return resp.With(c).Response(err)
+	}
+}
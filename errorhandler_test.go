@@ -0,0 +1,142 @@
+package fibererror_test
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/prongbang/fibererror"
+	"github.com/prongbang/goerror"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestErrorHandler(t *testing.T) {
+	app := fiber.New(fiber.Config{
+		ErrorHandler: fibererror.ErrorHandler(nil),
+	})
+	app.Get("/test", func(c *fiber.Ctx) error {
+		return goerror.NewForbidden()
+	})
+
+	resp, _ := app.Test(httptest.NewRequest("GET", "/test", nil))
+
+	if resp.StatusCode != http.StatusForbidden {
+		t.Error("Error", resp.StatusCode)
+	}
+}
+
+func TestErrorHandlerCustom(t *testing.T) {
+	customResp := NewCustomResponse()
+	app := fiber.New(fiber.Config{
+		ErrorHandler: fibererror.ErrorHandler(&fibererror.Config{
+			Custom: &customResp,
+		}),
+	})
+	app.Get("/test", func(c *fiber.Ctx) error {
+		return NewCustomError()
+	})
+
+	resp, _ := app.Test(httptest.NewRequest("GET", "/test", nil))
+	body, _ := io.ReadAll(resp.Body)
+
+	if resp.StatusCode != http.StatusBadRequest || !strings.Contains(string(body), "CUS001") {
+		t.Error("Error", resp.StatusCode, string(body))
+	}
+}
+
+func TestErrorHandlerRouteNotFound(t *testing.T) {
+	app := fiber.New(fiber.Config{
+		ErrorHandler: fibererror.ErrorHandler(nil),
+	})
+	app.Get("/test", func(c *fiber.Ctx) error {
+		return c.SendStatus(http.StatusOK)
+	})
+
+	resp, _ := app.Test(httptest.NewRequest("GET", "/unknown", nil))
+	body, _ := io.ReadAll(resp.Body)
+
+	if resp.StatusCode != http.StatusNotFound || !strings.Contains(string(body), goerror.CodeNotFound) {
+		t.Error("Error", resp.StatusCode, string(body))
+	}
+}
+
+func TestErrorHandlerMethodNotAllowed(t *testing.T) {
+	app := fiber.New(fiber.Config{
+		ErrorHandler: fibererror.ErrorHandler(nil),
+	})
+	app.Get("/test", func(c *fiber.Ctx) error {
+		return c.SendStatus(http.StatusOK)
+	})
+
+	resp, _ := app.Test(httptest.NewRequest("POST", "/test", nil))
+	body, _ := io.ReadAll(resp.Body)
+
+	if resp.StatusCode != http.StatusMethodNotAllowed || !strings.Contains(string(body), goerror.CodeMethodNotAllowed) {
+		t.Error("Error", resp.StatusCode, string(body))
+	}
+}
+
+func TestErrorHandlerFiberError(t *testing.T) {
+	app := fiber.New(fiber.Config{
+		ErrorHandler: fibererror.ErrorHandler(nil),
+	})
+	app.Post("/test", func(c *fiber.Ctx) error {
+		return fiber.ErrRequestEntityTooLarge
+	})
+
+	resp, _ := app.Test(httptest.NewRequest("POST", "/test", nil))
+	body, _ := io.ReadAll(resp.Body)
+
+	if resp.StatusCode != http.StatusRequestEntityTooLarge || !strings.Contains(string(body), goerror.CodeRequestEntityTooLarge) {
+		t.Error("Error", resp.StatusCode, string(body))
+	}
+}
+
+func TestFiberErrorBodyParser(t *testing.T) {
+	app := fiber.New()
+	app.Post("/test", func(c *fiber.Ctx) error {
+		var req struct{}
+		if err := c.BodyParser(&req); err != nil {
+			return response.With(c).Response(err)
+		}
+		return c.SendStatus(http.StatusOK)
+	})
+
+	req := httptest.NewRequest("POST", "/test", strings.NewReader("data"))
+	req.Header.Set(fiber.HeaderContentType, "application/octet-stream")
+	resp, _ := app.Test(req)
+	body, _ := io.ReadAll(resp.Body)
+
+	if resp.StatusCode != http.StatusUnprocessableEntity || !strings.Contains(string(body), goerror.CodeUnprocessableEntity) {
+		t.Error("Error", resp.StatusCode, string(body))
+	}
+}
+
+func TestFiberErrorMessage(t *testing.T) {
+	app := fiber.New()
+	app.Get("/test", func(c *fiber.Ctx) error {
+		return response.With(c).Response(fiber.NewError(http.StatusBadRequest, "invalid id"))
+	})
+
+	resp, _ := app.Test(httptest.NewRequest("GET", "/test", nil))
+	body, _ := io.ReadAll(resp.Body)
+
+	if resp.StatusCode != http.StatusBadRequest || !strings.Contains(string(body), `"code":"CLE000","message":"invalid id"`) {
+		t.Error("Error", resp.StatusCode, string(body))
+	}
+}
+
+func TestFiberErrorUnknownCode(t *testing.T) {
+	app := fiber.New()
+	app.Get("/test", func(c *fiber.Ctx) error {
+		return response.With(c).Response(fiber.NewError(599, "network connect timeout"))
+	})
+
+	resp, _ := app.Test(httptest.NewRequest("GET", "/test", nil))
+	body, _ := io.ReadAll(resp.Body)
+
+	if resp.StatusCode != 599 || string(body) != `{"code":"SVR599","message":"network connect timeout","data":null}` {
+		t.Error("Error", resp.StatusCode, string(body))
+	}
+}
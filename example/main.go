@@ -36,7 +36,7 @@ func (c *customResponse) Response(ctx *fiber.Ctx, err error) error {
 	case *CustomError:
 		return ctx.Status(http.StatusBadRequest).JSON(e)
 	}
-	return nil
+	return fibererror.ErrUnhandled
 }
 
 func NewCustomResponse() fibererror.Custom {
@@ -0,0 +1,110 @@
+package fibererror
+
+import (
+	"context"
+	"errors"
+	"github.com/prongbang/goerror"
+	"net/http"
+	"strconv"
+)
+
+// StatusClientClosedRequest is the non-standard status written for
+// context.Canceled when Fallback.Context is enabled.
+const StatusClientClosedRequest = 499
+
+// CodeClientClosedRequest is the code of ClientClosedRequest.
+const CodeClientClosedRequest = "CLE499"
+
+type ClientClosedRequest struct {
+	goerror.Body
+}
+
+// Error implements error.
+func (c *ClientClosedRequest) Error() string {
+	return c.Message
+}
+
+func NewClientClosedRequest() error {
+	return &ClientClosedRequest{
+		Body: goerror.Body{
+			Code:    CodeClientClosedRequest,
+			Message: "Client Closed Request",
+		},
+	}
+}
+
+// fallbackError is written for a status without a goerror type.
+type fallbackError struct {
+	goerror.Body
+}
+
+// Error implements error.
+func (f *fallbackError) Error() string {
+	return f.Message
+}
+
+// newFallbackError returns the fallbackError of status, whose code is derived
+// from the class of status the way goerror codes are prefixed, e.g. "SVR599".
+func newFallbackError(status int, message string) *fallbackError {
+	prefix := "SVR"
+	switch {
+	case status < http.StatusOK:
+		prefix = "INF"
+	case status < http.StatusMultipleChoices:
+		prefix = "SUC"
+	case status < http.StatusBadRequest:
+		prefix = "RED"
+	case status < http.StatusInternalServerError:
+		prefix = "CLE"
+	}
+	if message == "" {
+		message = http.StatusText(status)
+	}
+	return &fallbackError{Body: goerror.Body{Code: prefix + strconv.Itoa(status), Message: message}}
+}
+
+// fallback writes the Fallback response for err, by default 500 Internal
+// Server Error, and returns the error it was written for.
+func (s *httpResponse) fallback(err error) (error, error) {
+	f := s.Fallback
+	if f == nil {
+		f = &Fallback{}
+	}
+	if f.Context {
+		switch {
+		case errors.Is(err, context.DeadlineExceeded):
+			target := goerror.NewGatewayTimeout()
+			return target, s.write(Mapping{Status: http.StatusGatewayTimeout}, target, err)
+		case errors.Is(err, context.Canceled):
+			target := NewClientClosedRequest()
+			return target, s.write(Mapping{Status: StatusClientClosedRequest}, target, err)
+		}
+	}
+	if f.Response != nil {
+		e := f.Response(s.Ctx, err)
+		if e == nil {
+			s.extend(err)
+		}
+		return err, e
+	}
+
+	status := f.Status
+	if status == 0 {
+		status = http.StatusInternalServerError
+	}
+	target := statusError(status)
+	if target == nil {
+		target = newFallbackError(status, "")
+	}
+	if f.Code != "" || f.Message != "" {
+		body, _ := goerror.GetBody(target)
+		if f.Code != "" {
+			body.Code = f.Code
+		}
+		if f.Message != "" {
+			body.Message = f.Message
+		}
+		target = &fallbackError{Body: body}
+	}
+	return target, s.write(Mapping{Status: status}, target, err)
+}
@@ -0,0 +1,62 @@
+package fibererror_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/prongbang/fibererror"
+	"github.com/prongbang/goerror"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestFallbackDefault(t *testing.T) {
+	resp, body := respond(t, response, errors.New("db down"), nil)
+
+	if resp.StatusCode != http.StatusInternalServerError || !strings.Contains(body, goerror.CodeInternalServerError) {
+		t.Error("Error", resp.StatusCode, body)
+	}
+}
+
+func TestFallbackPolicy(t *testing.T) {
+	res := fibererror.New(&fibererror.Config{
+		Fallback: &fibererror.Fallback{
+			Status:  http.StatusServiceUnavailable,
+			Code:    "DB001",
+			Message: "Database unavailable",
+		},
+	})
+
+	resp, body := respond(t, res, errors.New("db down"), nil)
+
+	if resp.StatusCode != http.StatusServiceUnavailable || !strings.Contains(body, `"code":"DB001","message":"Database unavailable"`) {
+		t.Error("Error", resp.StatusCode, body)
+	}
+}
+
+func TestFallbackContext(t *testing.T) {
+	res := fibererror.New(&fibererror.Config{
+		Fallback: &fibererror.Fallback{
+			Context: true,
+		},
+	})
+
+	resp, _ := respond(t, res, fmt.Errorf("query: %w", context.DeadlineExceeded), nil)
+	if resp.StatusCode != http.StatusGatewayTimeout {
+		t.Error("Error", resp.StatusCode)
+	}
+
+	resp, body := respond(t, res, context.Canceled, nil)
+	if resp.StatusCode != fibererror.StatusClientClosedRequest || !strings.Contains(body, fibererror.CodeClientClosedRequest) {
+		t.Error("Error", resp.StatusCode, body)
+	}
+}
+
+func TestFallbackContextDisabled(t *testing.T) {
+	resp, _ := respond(t, response, context.DeadlineExceeded, nil)
+
+	if resp.StatusCode != http.StatusInternalServerError {
+		t.Error("Error", resp.StatusCode)
+	}
+}
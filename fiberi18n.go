@@ -0,0 +1,32 @@
+package fibererror
+
+import (
+	"github.com/gofiber/contrib/fiberi18n/v2"
+	"github.com/gofiber/fiber/v2"
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+)
+
+// FiberI18n returns an I18n that localizes messages with the fiberi18n
+// middleware. Template data is forwarded as go-i18n TemplateData, and its
+// "Count" entry as PluralCount:
+//
+//	# localize/en.yaml
+//	USR404: User {{.ID}} not found
+//	CRT413:
+//	  one: Maximum {{.Count}} item allowed
+//	  other: Maximum {{.Count}} items allowed
+func FiberI18n() *I18n {
+	return &I18n{
+		Enabled: true,
+		Localize: func(c *fiber.Ctx, code string) (string, error) {
+			return fiberi18n.Localize(c, code)
+		},
+		LocalizeData: func(c *fiber.Ctx, code string, data map[string]any) (string, error) {
+			return fiberi18n.Localize(c, &i18n.LocalizeConfig{
+				MessageID:    code,
+				TemplateData: data,
+				PluralCount:  data["Count"],
+			})
+		},
+	}
+}
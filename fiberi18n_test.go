@@ -0,0 +1,77 @@
+package fibererror_test
+
+import (
+	"fmt"
+	"github.com/gofiber/contrib/fiberi18n/v2"
+	"github.com/prongbang/fibererror"
+	"github.com/prongbang/goerror"
+	"golang.org/x/text/language"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+var messages = map[string]string{
+	"localize/en.yaml": "USR404: User {{.ID}} not found\n" +
+		"CRT413:\n  one: Maximum {{.Count}} item allowed\n  other: Maximum {{.Count}} items allowed\n",
+	"localize/th.yaml": "USR404: ไม่พบผู้ใช้ {{.ID}}\n" +
+		"CRT413:\n  other: สูงสุด {{.Count}} รายการ\n",
+}
+
+type LimitError struct {
+	goerror.Body
+	Max int `json:"-"`
+}
+
+// Error implements error.
+func (l *LimitError) Error() string {
+	return l.Message
+}
+
+// TemplateData implements the template data of the message.
+func (l *LimitError) TemplateData() map[string]any {
+	return map[string]any{"Count": l.Max}
+}
+
+var translator = fiberi18n.New(&fiberi18n.Config{
+	RootPath:        "localize",
+	AcceptLanguages: []language.Tag{language.English, language.Thai},
+	DefaultLanguage: language.English,
+	Loader: fiberi18n.LoaderFunc(func(path string) ([]byte, error) {
+		return []byte(messages[path]), nil
+	}),
+})
+
+var templates = func() fibererror.Response {
+	registry := fibererror.NewRegistry()
+	registry.Register(&CustomError{}, http.StatusNotFound)
+	registry.Register(&LimitError{}, http.StatusRequestEntityTooLarge)
+	return fibererror.New(&fibererror.Config{
+		Registry: registry,
+		I18n:     fibererror.FiberI18n(),
+	})
+}()
+
+func TestFiberI18nTemplateData(t *testing.T) {
+	err := fibererror.WithTemplateData(&CustomError{Body: goerror.Body{Code: "USR404"}}, map[string]any{"ID": 42})
+
+	_, en := respond(t, templates, fmt.Errorf("load: %w", err), nil, translator)
+	_, th := respond(t, templates, err, httptest.NewRequest("GET", "/test?lang=th", nil), translator)
+
+	if !strings.Contains(en, "User 42 not found") || !strings.Contains(th, "ไม่พบผู้ใช้ 42") {
+		t.Error("Error", en, th)
+	}
+}
+
+func TestFiberI18nPluralCount(t *testing.T) {
+	one := &LimitError{Body: goerror.Body{Code: "CRT413"}, Max: 1}
+	other := &LimitError{Body: goerror.Body{Code: "CRT413"}, Max: 10}
+
+	_, single := respond(t, templates, one, nil, translator)
+	_, plural := respond(t, templates, other, nil, translator)
+
+	if !strings.Contains(single, "Maximum 1 item allowed") || !strings.Contains(plural, "Maximum 10 items allowed") {
+		t.Error("Error", single, plural)
+	}
+}
@@ -0,0 +1,136 @@
+package fibererror
+
+import (
+	"fmt"
+	"github.com/gofiber/fiber/v2"
+	"log/slog"
+)
+
+// Event describes an error response written by Response.
+type Event struct {
+	// Status is the status code written.
+	Status int
+	// Code is the goerror.Body.Code of Target.
+	Code string
+	// Err is the error given to Response, including its wrappers.
+	Err error
+	// Target is the error in the chain of Err the response was written for.
+	Target error
+}
+
+// Hook observes the error responses written by Response.
+type Hook func(c *fiber.Ctx, e *Event)
+
+// Hooks returns a Hook calling each of hooks in order:
+//
+//	response := fibererror.New(&fibererror.Config{
+//		OnError: fibererror.Hooks(fibererror.NewSlogHook(), otel.NewHook()),
+//	})
+func Hooks(hooks ...Hook) Hook {
+	return func(c *fiber.Ctx, e *Event) {
+		for _, hook := range hooks {
+			if hook != nil {
+				hook(c, e)
+			}
+		}
+	}
+}
+
+// Slog configures NewSlogHook.
+type Slog struct {
+	// Logger defaults to slog.Default().
+	Logger *slog.Logger
+	// Suppress lists the codes that are not logged.
+	Suppress []string
+	// RequestIDKey is the Locals key of the request ID, defaults to
+	// "requestid" as set by the requestid middleware.
+	RequestIDKey string
+}
+
+// NewSlogHook returns a Hook that logs every error response with log/slog,
+// at Error level for 5xx, Warn for 4xx and Info otherwise:
+//
+//	response := fibererror.New(&fibererror.Config{
+//		OnError: fibererror.NewSlogHook(&fibererror.Slog{
+//			Suppress: []string{goerror.CodeNotFound},
+//		}),
+//	})
+func NewSlogHook(config ...*Slog) Hook {
+	cfg := &Slog{}
+	if len(config) > 0 && config[0] != nil {
+		cfg = config[0]
+	}
+	logger := cfg.Logger
+	if logger == nil {
+		logger = slog.Default()
+	}
+	key := cfg.RequestIDKey
+	if key == "" {
+		key = "requestid"
+	}
+	suppress := make(map[string]bool, len(cfg.Suppress))
+	for _, code := range cfg.Suppress {
+		suppress[code] = true
+	}
+
+	return func(c *fiber.Ctx, e *Event) {
+		if suppress[e.Code] {
+			return
+		}
+		level := slog.LevelInfo
+		switch {
+		case e.Status >= fiber.StatusInternalServerError:
+			level = slog.LevelError
+		case e.Status >= fiber.StatusBadRequest:
+			level = slog.LevelWarn
+		}
+		attrs := []slog.Attr{
+			slog.Int("status", e.Status),
+			slog.String("code", e.Code),
+			slog.String("method", c.Method()),
+			slog.String("route", c.Route().Path),
+		}
+		if e.Err != nil {
+			attrs = append(attrs,
+				slog.String("error", e.Err.Error()),
+				slog.Any("error_chain", typesOf(e.Err)),
+			)
+		}
+		if id := localRequestID(c, key); id != "" {
+			attrs = append(attrs, slog.String("request_id", id))
+		}
+		logger.LogAttrs(c.UserContext(), level, "error response", attrs...)
+	}
+}
+
+// localRequestID returns the request ID stored in Locals under key, or the
+// X-Request-ID response header.
+func localRequestID(c *fiber.Ctx, key string) string {
+	if id, ok := c.Locals(key).(string); ok && id != "" {
+		return id
+	}
+	return c.GetRespHeader(fiber.HeaderXRequestID)
+}
+
+// chain returns the errors in the tree of err, outermost first, without the
+// annotations of the With functions.
+func chain(err error) []error {
+	var links []error
+	walk(err, func(e error) bool {
+		if _, ok := e.(*annotation); !ok {
+			links = append(links, e)
+		}
+		return false
+	})
+	return links
+}
+
+// typesOf returns the Go type names of the errors in the tree of err.
+func typesOf(err error) []string {
+	links := chain(err)
+	types := make([]string, len(links))
+	for i, e := range links {
+		types[i] = fmt.Sprintf("%T", e)
+	}
+	return types
+}
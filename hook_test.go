@@ -0,0 +1,128 @@
+package fibererror_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/requestid"
+	"github.com/prongbang/fibererror"
+	"github.com/prongbang/goerror"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestOnError(t *testing.T) {
+	var event *fibererror.Event
+	app := fiber.New()
+
+	res := fibererror.New(&fibererror.Config{
+		OnError: func(c *fiber.Ctx, e *fibererror.Event) {
+			event = e
+		},
+	})
+
+	err := fmt.Errorf("load user: %w", goerror.NewNotFound())
+	app.Get("/test", func(c *fiber.Ctx) error {
+		return res.With(c).Response(err)
+	})
+
+	_, _ = app.Test(httptest.NewRequest("GET", "/test", nil))
+
+	if event == nil || event.Status != http.StatusNotFound || event.Code != goerror.CodeNotFound ||
+		event.Err != err || !errors.Is(err, event.Target) {
+		t.Error("Error", event)
+	}
+}
+
+func TestSlogHook(t *testing.T) {
+	var buf bytes.Buffer
+	app := fiber.New()
+	app.Use(requestid.New(requestid.Config{
+		Generator: func() string { return "req-1" },
+	}))
+
+	res := fibererror.New(&fibererror.Config{
+		OnError: fibererror.NewSlogHook(&fibererror.Slog{
+			Logger: slog.New(slog.NewJSONHandler(&buf, nil)),
+		}),
+	})
+
+	app.Get("/users/:id", func(c *fiber.Ctx) error {
+		return res.With(c).Response(fmt.Errorf("load user: %w", goerror.NewNotFound()))
+	})
+
+	_, _ = app.Test(httptest.NewRequest("GET", "/users/42", nil))
+
+	var record map[string]any
+	_ = json.Unmarshal(buf.Bytes(), &record)
+
+	if record["level"] != "WARN" || record["status"] != float64(http.StatusNotFound) ||
+		record["code"] != goerror.CodeNotFound || record["error"] != "load user: Not Found" ||
+		record["route"] != "/users/:id" || record["method"] != "GET" || record["request_id"] != "req-1" {
+		t.Error("Error", record)
+	}
+	if chain, _ := record["error_chain"].([]any); len(chain) != 2 || chain[1] != "*goerror.NotFound" {
+		t.Error("Error", record["error_chain"])
+	}
+}
+
+func TestSlogHookServerError(t *testing.T) {
+	var buf bytes.Buffer
+	res := fibererror.New(&fibererror.Config{
+		OnError: fibererror.NewSlogHook(&fibererror.Slog{
+			Logger: slog.New(slog.NewJSONHandler(&buf, nil)),
+		}),
+	})
+
+	_, _ = respond(t, res, errors.New("db down"), nil)
+
+	var record map[string]any
+	_ = json.Unmarshal(buf.Bytes(), &record)
+
+	if record["level"] != "ERROR" || record["status"] != float64(http.StatusInternalServerError) {
+		t.Error("Error", record)
+	}
+}
+
+func TestSlogHookSuppress(t *testing.T) {
+	var buf bytes.Buffer
+	res := fibererror.New(&fibererror.Config{
+		OnError: fibererror.NewSlogHook(&fibererror.Slog{
+			Logger:   slog.New(slog.NewJSONHandler(&buf, nil)),
+			Suppress: []string{goerror.CodeNotFound},
+		}),
+	})
+
+	_, _ = respond(t, res, goerror.NewNotFound(), nil)
+
+	if buf.Len() != 0 {
+		t.Error("Error", buf.String())
+	}
+}
+
+func TestHooks(t *testing.T) {
+	var calls []string
+	app := fiber.New()
+
+	res := fibererror.New(&fibererror.Config{
+		OnError: fibererror.Hooks(
+			func(c *fiber.Ctx, e *fibererror.Event) { calls = append(calls, "first") },
+			nil,
+			func(c *fiber.Ctx, e *fibererror.Event) { calls = append(calls, "second") },
+		),
+	})
+
+	app.Get("/test", func(c *fiber.Ctx) error {
+		return res.With(c).Response(goerror.NewNotFound())
+	})
+
+	_, _ = app.Test(httptest.NewRequest("GET", "/test", nil))
+
+	if fmt.Sprint(calls) != "[first second]" {
+		t.Error("Error", calls)
+	}
+}
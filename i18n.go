@@ -0,0 +1,129 @@
+package fibererror
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/prongbang/goerror"
+	"reflect"
+)
+
+// defaultMessages are the messages set by the goerror constructors by code.
+var defaultMessages = func() map[string]string {
+	messages := make(map[string]string, len(defaults))
+	for _, d := range defaults {
+		if body, err := goerror.GetBody(d.New()); err == nil {
+			messages[body.Code] = body.Message
+		}
+	}
+	return messages
+}()
+
+// LocalizeFunc returns the localized message of code with template data,
+// and whether there is one.
+type LocalizeFunc func(code string, data map[string]any) (string, bool)
+
+// localize returns target with its message localized by code when I18n is
+// enabled, using the template data found in the chain of err. Only empty or
+// default goerror messages are localized, so an explicit message such as
+// fiber.NewError(400, "invalid id") is kept. An error implementing
+// Localize(LocalizeFunc) error, such as one with per-field details, also
+// localizes its other messages. target itself is never modified.
+func (s *httpResponse) localize(target error, err error) error {
+	if s.I18n == nil || !s.I18n.Enabled {
+		return target
+	}
+	if body, ok := getBody(target); ok && body.Code != "" &&
+		(body.Message == "" || body.Message == defaultMessages[body.Code]) {
+		if localize, ok := s.translate(body.Code, templateData(err)); ok {
+			target = withMessage(target, localize)
+		}
+	}
+	if l, ok := target.(interface{ Localize(LocalizeFunc) error }); ok {
+		if e := l.Localize(s.translate); e != nil {
+			target = e
+		}
+	}
+	return target
+}
+
+// translate returns the localized message of code when I18n is enabled.
+func (s *httpResponse) translate(code string, data map[string]any) (string, bool) {
+	if s.I18n == nil || !s.I18n.Enabled {
+		return "", false
+	}
+	var (
+		localize string
+		e        error
+	)
+	switch {
+	case s.I18n.LocalizeData != nil:
+		localize, e = s.I18n.LocalizeData(s.Ctx, code, data)
+	case s.I18n.Localize != nil:
+		localize, e = s.I18n.Localize(s.Ctx, code)
+	default:
+		return "", false
+	}
+	return localize, e == nil && localize != ""
+}
+
+// withMessage returns a copy of target with message.
+func withMessage(target error, message string) error {
+	if fe, ok := target.(*fiber.Error); ok {
+		return &fiber.Error{Code: fe.Code, Message: message}
+	}
+	if _, ok := getBody(target); !ok {
+		return target
+	}
+	target = clone(target)
+	goerror.SetMessage(target, message)
+	return target
+}
+
+// templateValues is the template data attached by WithTemplateData.
+type templateValues map[string]any
+
+// WithTemplateData wraps err with template data for its localized message,
+// e.g. "User {{.ID}} not found". A "Count" entry is used as the plural count
+// by FiberI18n.
+func WithTemplateData(err error, data map[string]any) error {
+	return annotate(err, templateValues(data))
+}
+
+// templateData merges the template data found in the chain of err. Outer
+// errors take precedence over the errors they wrap.
+func templateData(err error) map[string]any {
+	var data map[string]any
+	walk(err, func(e error) bool {
+		var values map[string]any
+		switch t := valueOf(e).(type) {
+		case templateValues:
+			values = t
+		case interface{ TemplateData() map[string]any }:
+			values = t.TemplateData()
+		}
+		for k, v := range values {
+			if data == nil {
+				data = make(map[string]any)
+			}
+			if _, exists := data[k]; !exists {
+				data[k] = v
+			}
+		}
+		return false
+	})
+	return data
+}
+
+// clone returns a shallow copy of the struct err points to, so a message can
+// be set without modifying an error value that may be shared.
+func clone(err error) error {
+	v := reflect.ValueOf(err)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return err
+	}
+	c := reflect.New(v.Elem().Type())
+	c.Elem().Set(v.Elem())
+	if e, ok := c.Interface().(error); ok {
+		return e
+	}
+	return err
+}
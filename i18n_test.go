@@ -0,0 +1,86 @@
+package fibererror_test
+
+import (
+	"errors"
+	"github.com/gofiber/fiber/v2"
+	"github.com/prongbang/fibererror"
+	"github.com/prongbang/goerror"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+var translations = map[string]map[string]string{
+	"th": {
+		goerror.CodeUnauthorized:        "ไม่ได้รับอนุญาต",
+		goerror.CodeBadRequest:          "คำขอไม่ถูกต้อง",
+		goerror.CodeInternalServerError: "เกิดข้อผิดพลาดภายในเซิร์ฟเวอร์",
+		"CUS001":                        "ดัดแปลง 001",
+	},
+}
+
+var localized = &fibererror.I18n{
+	Enabled: true,
+	Localize: func(c *fiber.Ctx, code string) (string, error) {
+		if message, ok := translations[c.Get(fiber.HeaderAcceptLanguage)][code]; ok {
+			return message, nil
+		}
+		return "", errors.New("message not found")
+	},
+}
+
+func TestI18nGoError(t *testing.T) {
+	req := httptest.NewRequest("GET", "/test", nil)
+	req.Header.Set(fiber.HeaderAcceptLanguage, "th")
+	resp, body := respond(t, fibererror.New(&fibererror.Config{I18n: localized}), goerror.NewUnauthorized(), req)
+
+	if resp.StatusCode != http.StatusUnauthorized || !strings.Contains(body, "ไม่ได้รับอนุญาต") {
+		t.Error("Error", resp.StatusCode, body)
+	}
+}
+
+func TestI18nCustom(t *testing.T) {
+	customResp := NewCustomResponse()
+	res := fibererror.New(&fibererror.Config{Custom: &customResp, I18n: localized})
+
+	req := httptest.NewRequest("GET", "/test", nil)
+	req.Header.Set(fiber.HeaderAcceptLanguage, "th")
+	resp, body := respond(t, res, NewCustomError(), req)
+
+	if resp.StatusCode != http.StatusBadRequest || !strings.Contains(body, "ดัดแปลง 001") {
+		t.Error("Error", resp.StatusCode, body)
+	}
+}
+
+func TestI18nExplicitMessage(t *testing.T) {
+	req := httptest.NewRequest("GET", "/test", nil)
+	req.Header.Set(fiber.HeaderAcceptLanguage, "th")
+	resp, body := respond(t, fibererror.New(&fibererror.Config{I18n: localized}), goerror.NewBadRequest("invalid id"), req)
+
+	if resp.StatusCode != http.StatusBadRequest || !strings.Contains(body, "invalid id") {
+		t.Error("Error", resp.StatusCode, body)
+	}
+}
+
+func TestI18nMissingMessage(t *testing.T) {
+	req := httptest.NewRequest("GET", "/test", nil)
+	req.Header.Set(fiber.HeaderAcceptLanguage, "th")
+	resp, body := respond(t, fibererror.New(&fibererror.Config{I18n: localized}), goerror.NewNotFound(), req)
+
+	if resp.StatusCode != http.StatusNotFound || !strings.Contains(body, "Not Found") {
+		t.Error("Error", resp.StatusCode, body)
+	}
+}
+
+func TestI18nSharedError(t *testing.T) {
+	shared := goerror.NewUnauthorized()
+
+	req := httptest.NewRequest("GET", "/test", nil)
+	req.Header.Set(fiber.HeaderAcceptLanguage, "th")
+	_, _ = respond(t, fibererror.New(&fibererror.Config{I18n: localized}), shared, req)
+
+	if shared.Error() != http.StatusText(http.StatusUnauthorized) {
+		t.Error("Error", shared.Error())
+	}
+}
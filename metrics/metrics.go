@@ -0,0 +1,128 @@
+// Package metrics counts the error responses written by fibererror with
+// Prometheus.
+package metrics
+
+import (
+	"errors"
+	"github.com/gofiber/fiber/v2"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prongbang/fibererror"
+	"strconv"
+	"sync"
+)
+
+// Labels written for values that are bucketed to bound cardinality.
+const (
+	UnknownRoute = "unknown"
+	OtherCode    = "other"
+)
+
+// Config configures NewHook.
+type Config struct {
+	// Registerer defaults to prometheus.DefaultRegisterer, which is served
+	// by promhttp.Handler().
+	Registerer prometheus.Registerer
+	// Namespace and Subsystem prefix the name of the counter,
+	// fibererror_responses_total.
+	Namespace string
+	Subsystem string
+	// MaxCodes is the number of distinct codes counted, defaults to 100.
+	// Further codes are counted as OtherCode.
+	MaxCodes int
+}
+
+// NewHook returns a fibererror.Hook that counts every error response by
+// route template, method, status and goerror.Body.Code. Requests that
+// matched no route are counted as UnknownRoute, so paths never become
+// labels. It panics when the counter cannot be registered, like
+// prometheus.MustRegister, and reuses a counter registered before:
+//
+//	response := fibererror.New(&fibererror.Config{
+//		OnError: metrics.NewHook(),
+//	})
+//	app.Get("/metrics", adaptor.HTTPHandler(promhttp.Handler()))
+func NewHook(config ...*Config) fibererror.Hook {
+	cfg := &Config{}
+	if len(config) > 0 && config[0] != nil {
+		cfg = config[0]
+	}
+	registerer := cfg.Registerer
+	if registerer == nil {
+		registerer = prometheus.DefaultRegisterer
+	}
+	maxCodes := cfg.MaxCodes
+	if maxCodes <= 0 {
+		maxCodes = 100
+	}
+
+	counter := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: cfg.Namespace,
+		Subsystem: cfg.Subsystem,
+		Name:      "fibererror_responses_total",
+		Help:      "Number of error responses written by fibererror.",
+	}, []string{"route", "method", "status", "code"})
+	if err := registerer.Register(counter); err != nil {
+		var are prometheus.AlreadyRegisteredError
+		if !errors.As(err, &are) {
+			panic(err)
+		}
+		counter = are.ExistingCollector.(*prometheus.CounterVec)
+	}
+
+	codes := &codeSet{max: maxCodes, seen: make(map[string]bool)}
+	return func(c *fiber.Ctx, e *fibererror.Event) {
+		counter.WithLabelValues(
+			route(c),
+			c.Method(),
+			strconv.Itoa(e.Status),
+			codes.label(e.Code),
+		).Inc()
+	}
+}
+
+// route returns the template of the matched route, or UnknownRoute when no
+// route other than middleware matches the method and path of the request.
+// Fiber's router answers such a request with a 404 or 405 from the route of
+// the last middleware it went through, while a handler of a matched route
+// may return a 404 of its own.
+func route(c *fiber.Ctx) string {
+	r := c.Route()
+	if len(r.Handlers) == 0 || !matched(c) {
+		return UnknownRoute
+	}
+	return r.Path
+}
+
+// matched reports whether a route other than middleware matches the method
+// and path of the request.
+func matched(c *fiber.Ctx) bool {
+	app := c.App()
+	cfg := app.Config()
+	for _, r := range app.GetRoutes(true) {
+		if r.Method == c.Method() && fiber.RoutePatternMatch(c.Path(), r.Path, cfg) {
+			return true
+		}
+	}
+	return false
+}
+
+// codeSet bounds the number of distinct code labels.
+type codeSet struct {
+	mu   sync.Mutex
+	max  int
+	seen map[string]bool
+}
+
+// label returns code, or OtherCode once max other codes have been seen.
+func (s *codeSet) label(code string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.seen[code] {
+		return code
+	}
+	if len(s.seen) >= s.max {
+		return OtherCode
+	}
+	s.seen[code] = true
+	return code
+}
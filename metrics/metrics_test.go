@@ -0,0 +1,164 @@
+package metrics_test
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/prongbang/fibererror"
+	"github.com/prongbang/fibererror/metrics"
+	"github.com/prongbang/goerror"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestHook(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	res := fibererror.New(&fibererror.Config{
+		OnError: metrics.NewHook(&metrics.Config{Registerer: registry}),
+	})
+	app := fiber.New(fiber.Config{
+		ErrorHandler: func(c *fiber.Ctx, err error) error {
+			return res.With(c).Response(err)
+		},
+	})
+	app.Use(func(c *fiber.Ctx) error {
+		return c.Next()
+	})
+	app.Get("/users/:id", func(c *fiber.Ctx) error {
+		return goerror.NewNotFound()
+	})
+	app.Get("/orders/:id", func(c *fiber.Ctx) error {
+		return goerror.NewInternalServerError()
+	})
+
+	for _, path := range []string{"/users/1", "/users/2", "/orders/1", "/missing"} {
+		_, _ = app.Test(httptest.NewRequest("GET", path, nil))
+	}
+
+	expected := `
+# HELP fibererror_responses_total Number of error responses written by fibererror.
+# TYPE fibererror_responses_total counter
+fibererror_responses_total{code="CLE004",method="GET",route="/users/:id",status="404"} 2
+fibererror_responses_total{code="CLE004",method="GET",route="unknown",status="404"} 1
+fibererror_responses_total{code="SVR000",method="GET",route="/orders/:id",status="500"} 1
+`
+	if err := testutil.GatherAndCompare(registry, strings.NewReader(expected)); err != nil {
+		t.Error("Error", err)
+	}
+}
+
+func TestHookMaxCodes(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	res := fibererror.New(&fibererror.Config{
+		OnError: metrics.NewHook(&metrics.Config{Registerer: registry, MaxCodes: 1}),
+	})
+	app := fiber.New(fiber.Config{
+		ErrorHandler: func(c *fiber.Ctx, err error) error {
+			return res.With(c).Response(err)
+		},
+	})
+	app.Get("/users/:id", func(c *fiber.Ctx) error {
+		return goerror.NewNotFound()
+	})
+	app.Get("/conflicts/:id", func(c *fiber.Ctx) error {
+		return goerror.NewConflict()
+	})
+
+	for _, path := range []string{"/users/1", "/conflicts/1", "/users/2"} {
+		_, _ = app.Test(httptest.NewRequest("GET", path, nil))
+	}
+
+	expected := `
+# HELP fibererror_responses_total Number of error responses written by fibererror.
+# TYPE fibererror_responses_total counter
+fibererror_responses_total{code="CLE004",method="GET",route="/users/:id",status="404"} 2
+fibererror_responses_total{code="other",method="GET",route="/conflicts/:id",status="409"} 1
+`
+	if err := testutil.GatherAndCompare(registry, strings.NewReader(expected)); err != nil {
+		t.Error("Error", err)
+	}
+}
+
+func TestHookRegisteredTwice(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	first := metrics.NewHook(&metrics.Config{Registerer: registry})
+	second := metrics.NewHook(&metrics.Config{Registerer: registry})
+
+	app := fiber.New()
+	app.Get("/test", func(c *fiber.Ctx) error {
+		first(c, &fibererror.Event{Status: 404, Code: goerror.CodeNotFound})
+		second(c, &fibererror.Event{Status: 404, Code: goerror.CodeNotFound})
+		return nil
+	})
+	_, _ = app.Test(httptest.NewRequest("GET", "/test", nil))
+
+	if n := testutil.CollectAndCount(registry); n != 1 {
+		t.Error("Error", n)
+	}
+}
+
+func TestHookMethodNotAllowed(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	res := fibererror.New(&fibererror.Config{
+		OnError: metrics.NewHook(&metrics.Config{Registerer: registry}),
+	})
+	app := fiber.New(fiber.Config{
+		ErrorHandler: func(c *fiber.Ctx, err error) error {
+			return res.With(c).Response(err)
+		},
+	})
+	app.Use(func(c *fiber.Ctx) error {
+		return c.Next()
+	})
+	app.Get("/users/:id", func(c *fiber.Ctx) error {
+		return c.SendStatus(fiber.StatusOK)
+	})
+
+	_, _ = app.Test(httptest.NewRequest("POST", "/users/1", nil))
+
+	expected := `
+# HELP fibererror_responses_total Number of error responses written by fibererror.
+# TYPE fibererror_responses_total counter
+fibererror_responses_total{code="CLE005",method="POST",route="unknown",status="405"} 1
+`
+	if err := testutil.GatherAndCompare(registry, strings.NewReader(expected)); err != nil {
+		t.Error("Error", err)
+	}
+}
+
+func TestHookMatchedRouteNotFound(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	res := fibererror.New(&fibererror.Config{
+		OnError: metrics.NewHook(&metrics.Config{Registerer: registry}),
+	})
+	app := fiber.New(fiber.Config{
+		ErrorHandler: func(c *fiber.Ctx, err error) error {
+			return res.With(c).Response(err)
+		},
+	})
+	app.Use(func(c *fiber.Ctx) error {
+		return c.Next()
+	})
+	app.Get("/users/:id", func(c *fiber.Ctx) error {
+		return fiber.ErrNotFound
+	})
+	app.Get("/orders/:id", func(c *fiber.Ctx) error {
+		return fiber.NewError(fiber.StatusNotFound, "order not found")
+	})
+
+	for _, path := range []string{"/users/1", "/orders/1", "/missing"} {
+		_, _ = app.Test(httptest.NewRequest("GET", path, nil))
+	}
+
+	expected := `
+# HELP fibererror_responses_total Number of error responses written by fibererror.
+# TYPE fibererror_responses_total counter
+fibererror_responses_total{code="CLE004",method="GET",route="/orders/:id",status="404"} 1
+fibererror_responses_total{code="CLE004",method="GET",route="/users/:id",status="404"} 1
+fibererror_responses_total{code="CLE004",method="GET",route="unknown",status="404"} 1
+`
+	if err := testutil.GatherAndCompare(registry, strings.NewReader(expected)); err != nil {
+		t.Error("Error", err)
+	}
+}
@@ -0,0 +1,51 @@
+// Package otel records the error responses written by fibererror on the
+// OpenTelemetry span of the request.
+package otel
+
+import (
+	"fmt"
+	"github.com/gofiber/fiber/v2"
+	"github.com/prongbang/fibererror"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Attribute keys set on the span.
+const (
+	KeyStatusCode = attribute.Key("http.status_code")
+	KeyErrorCode  = attribute.Key("error.code")
+	KeyErrorType  = attribute.Key("error.type")
+)
+
+// NewHook returns a fibererror.Hook that records the error on the span of
+// Ctx.UserContext() with its status code, goerror.Body.Code and Go type, and
+// sets the span status to Error for 5xx:
+//
+//	response := fibererror.New(&fibererror.Config{
+//		OnError: otel.NewHook(),
+//	})
+func NewHook() fibererror.Hook {
+	return func(c *fiber.Ctx, e *fibererror.Event) {
+		span := trace.SpanFromContext(c.UserContext())
+		if !span.IsRecording() {
+			return
+		}
+		attrs := []attribute.KeyValue{
+			KeyStatusCode.Int(e.Status),
+			KeyErrorCode.String(e.Code),
+		}
+		if e.Target != nil {
+			attrs = append(attrs, KeyErrorType.String(fmt.Sprintf("%T", e.Target)))
+		}
+		span.SetAttributes(attrs...)
+		description := ""
+		if e.Err != nil {
+			span.RecordError(e.Err, trace.WithAttributes(attrs...))
+			description = e.Err.Error()
+		}
+		if e.Status >= fiber.StatusInternalServerError {
+			span.SetStatus(codes.Error, description)
+		}
+	}
+}
@@ -0,0 +1,98 @@
+package otel_test
+
+import (
+	"fmt"
+	"github.com/gofiber/fiber/v2"
+	"github.com/prongbang/fibererror"
+	"github.com/prongbang/fibererror/otel"
+	"github.com/prongbang/goerror"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"net/http/httptest"
+	"testing"
+)
+
+func attributes(span sdktrace.ReadOnlySpan) map[attribute.Key]attribute.Value {
+	attrs := make(map[attribute.Key]attribute.Value)
+	for _, kv := range span.Attributes() {
+		attrs[kv.Key] = kv.Value
+	}
+	return attrs
+}
+
+func TestHook(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	tracer := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)).Tracer("test")
+	res := fibererror.New(&fibererror.Config{
+		OnError: otel.NewHook(),
+	})
+
+	app := fiber.New()
+	app.Use(func(c *fiber.Ctx) error {
+		ctx, span := tracer.Start(c.UserContext(), c.Path())
+		defer span.End()
+		c.SetUserContext(ctx)
+		return c.Next()
+	})
+	app.Get("/test", func(c *fiber.Ctx) error {
+		return res.With(c).Response(fmt.Errorf("load user: %w", goerror.NewNotFound()))
+	})
+
+	_, _ = app.Test(httptest.NewRequest("GET", "/test", nil))
+
+	spans := recorder.Ended()
+	if len(spans) != 1 {
+		t.Fatal("Error", len(spans))
+	}
+	span := spans[0]
+
+	attrs := attributes(span)
+	if attrs[otel.KeyStatusCode].AsInt64() != 404 ||
+		attrs[otel.KeyErrorCode].AsString() != goerror.CodeNotFound ||
+		attrs[otel.KeyErrorType].AsString() != "*goerror.NotFound" {
+		t.Error("Error", attrs)
+	}
+	if span.Status().Code != codes.Unset {
+		t.Error("Error", span.Status())
+	}
+	events := span.Events()
+	if len(events) != 1 || events[0].Name != "exception" {
+		t.Error("Error", events)
+	}
+}
+
+func TestHookServerError(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	tracer := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)).Tracer("test")
+	res := fibererror.New(&fibererror.Config{
+		OnError: otel.NewHook(),
+	})
+
+	app := fiber.New()
+	app.Use(func(c *fiber.Ctx) error {
+		ctx, span := tracer.Start(c.UserContext(), c.Path())
+		defer span.End()
+		c.SetUserContext(ctx)
+		return c.Next()
+	})
+	app.Get("/test", func(c *fiber.Ctx) error {
+		return res.With(c).Response(goerror.NewInternalServerError())
+	})
+
+	_, _ = app.Test(httptest.NewRequest("GET", "/test", nil))
+
+	spans := recorder.Ended()
+	if len(spans) != 1 {
+		t.Fatal("Error", len(spans))
+	}
+	span := spans[0]
+
+	if span.Status().Code != codes.Error || span.Status().Description != "Internal Server Error" {
+		t.Error("Error", span.Status())
+	}
+	if attributes(span)[otel.KeyStatusCode].AsInt64() != 500 {
+		t.Error("Error", span.Attributes())
+	}
+}
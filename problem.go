@@ -0,0 +1,139 @@
+package fibererror
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/gofiber/fiber/v2"
+	"github.com/prongbang/goerror"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// MIMEApplicationProblemJSON is the media type of RFC 9457 problem details.
+const MIMEApplicationProblemJSON = "application/problem+json"
+
+// Format is the shape of a written error body.
+type Format int
+
+const (
+	// FormatBody writes the error itself, e.g. {"code":"CLE004","message":"Not Found"}.
+	FormatBody Format = iota
+	// FormatProblem writes an RFC 9457 problem details document.
+	FormatProblem
+)
+
+// Problem configures FormatProblem.
+type Problem struct {
+	// TypeBase is joined with goerror.Body.Code to build the type URI,
+	// e.g. "https://example.com/errors/" gives "https://example.com/errors/CLE004".
+	// When empty, type is "about:blank".
+	TypeBase string
+	// Instance returns the instance URI, defaults to the request path.
+	Instance func(c *fiber.Ctx) string
+}
+
+// ProblemDetails is an RFC 9457 problem details document. Extensions are
+// written as top-level members next to the standard ones.
+type ProblemDetails struct {
+	Type       string         `json:"type"`
+	Title      string         `json:"title"`
+	Status     int            `json:"status"`
+	Detail     string         `json:"detail,omitempty"`
+	Instance   string         `json:"instance,omitempty"`
+	Extensions map[string]any `json:"-"`
+}
+
+// MarshalJSON implements json.Marshaler.
+func (p ProblemDetails) MarshalJSON() ([]byte, error) {
+	type problem ProblemDetails
+	b, err := json.Marshal(problem(p))
+	if err != nil || len(p.Extensions) == 0 {
+		return b, err
+	}
+	names := make([]string, 0, len(p.Extensions))
+	for name := range p.Extensions {
+		if !problemMembers[name] {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	buf := bytes.NewBuffer(b[:len(b)-1])
+	for _, name := range names {
+		k, _ := json.Marshal(name)
+		v, err := json.Marshal(p.Extensions[name])
+		if err != nil {
+			return nil, err
+		}
+		buf.WriteByte(',')
+		buf.Write(k)
+		buf.WriteByte(':')
+		buf.Write(v)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// problemMembers are the standard members that extensions cannot replace.
+var problemMembers = map[string]bool{
+	"type": true, "title": true, "status": true, "detail": true, "instance": true,
+}
+
+// problem builds the problem details of err written with status. The message
+// becomes detail, and other fields of err, such as code, data or those of a
+// custom error struct, become extension members.
+func (s *httpResponse) problem(status int, err error) ProblemDetails {
+	body := bodyOf(err)
+	p := ProblemDetails{
+		Type:     "about:blank",
+		Title:    http.StatusText(status),
+		Status:   status,
+		Detail:   body.Message,
+		Instance: s.Ctx.Path(),
+	}
+	if s.Problem != nil {
+		if s.Problem.TypeBase != "" && body.Code != "" {
+			p.Type = strings.TrimSuffix(s.Problem.TypeBase, "/") + "/" + body.Code
+		}
+		if s.Problem.Instance != nil {
+			p.Instance = s.Problem.Instance(s.Ctx)
+		}
+	}
+	if fields := fieldsOf(err); len(fields) > 0 {
+		p.Extensions = make(map[string]any, len(fields))
+		for k, v := range fields {
+			if k != "message" && v != nil {
+				p.Extensions[k] = v
+			}
+		}
+	}
+	return p
+}
+
+// bodyOf returns the code and message of err.
+func bodyOf(err error) goerror.Body {
+	if err == nil {
+		return goerror.Body{}
+	}
+	if body, ok := getBody(err); ok {
+		return body
+	}
+	if fe, ok := err.(*fiber.Error); ok {
+		return goerror.Body{Message: fe.Message}
+	}
+	return goerror.Body{Message: err.Error()}
+}
+
+// fieldsOf returns the JSON members of err, or nil when it does not encode
+// as a JSON object.
+func fieldsOf(err error) map[string]any {
+	b, e := json.Marshal(err)
+	if e != nil {
+		return nil
+	}
+	var fields map[string]any
+	if json.Unmarshal(b, &fields) != nil {
+		return nil
+	}
+	return fields
+}
@@ -0,0 +1,101 @@
+package fibererror_test
+
+import (
+	"encoding/json"
+	"github.com/gofiber/fiber/v2"
+	"github.com/prongbang/fibererror"
+	"github.com/prongbang/goerror"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type OutOfCreditError struct {
+	goerror.Body
+	Balance int `json:"balance"`
+}
+
+// Error implements error.
+func (o *OutOfCreditError) Error() string {
+	return o.Message
+}
+
+func TestProblem(t *testing.T) {
+	app := fiber.New()
+
+	res := fibererror.New(&fibererror.Config{
+		Format: fibererror.FormatProblem,
+		Problem: &fibererror.Problem{
+			TypeBase: "https://example.com/errors/",
+		},
+	})
+
+	app.Get("/test", func(c *fiber.Ctx) error {
+		return res.With(c).Response(goerror.NewNotFound())
+	})
+
+	resp, _ := app.Test(httptest.NewRequest("GET", "/test", nil))
+	var problem map[string]any
+	_ = json.NewDecoder(resp.Body).Decode(&problem)
+
+	if resp.StatusCode != http.StatusNotFound ||
+		resp.Header.Get(fiber.HeaderContentType) != fibererror.MIMEApplicationProblemJSON ||
+		problem["type"] != "https://example.com/errors/CLE004" ||
+		problem["title"] != "Not Found" ||
+		problem["status"] != float64(http.StatusNotFound) ||
+		problem["detail"] != "Not Found" ||
+		problem["instance"] != "/test" {
+		t.Error("Error", resp.StatusCode, problem)
+	}
+}
+
+func TestProblemAboutBlank(t *testing.T) {
+	app := fiber.New()
+
+	res := fibererror.New(&fibererror.Config{
+		Format: fibererror.FormatProblem,
+	})
+
+	app.Get("/test", func(c *fiber.Ctx) error {
+		return res.With(c).Response(goerror.NewUnauthorized())
+	})
+
+	resp, _ := app.Test(httptest.NewRequest("GET", "/test", nil))
+	var problem map[string]any
+	_ = json.NewDecoder(resp.Body).Decode(&problem)
+
+	if problem["type"] != "about:blank" || problem["code"] != goerror.CodeUnauthorized {
+		t.Error("Error", problem)
+	}
+}
+
+func TestProblemExtensions(t *testing.T) {
+	app := fiber.New()
+
+	registry := fibererror.NewRegistry()
+	registry.Register(&OutOfCreditError{}, http.StatusForbidden)
+	res := fibererror.New(&fibererror.Config{
+		Registry: registry,
+		Format:   fibererror.FormatProblem,
+	})
+
+	app.Get("/test", func(c *fiber.Ctx) error {
+		return res.With(c).Response(&OutOfCreditError{
+			Body:    goerror.Body{Code: "CRD001", Message: "Your current balance is 30"},
+			Balance: 30,
+		})
+	})
+
+	resp, _ := app.Test(httptest.NewRequest("GET", "/test", nil))
+	var problem map[string]any
+	_ = json.NewDecoder(resp.Body).Decode(&problem)
+
+	if resp.StatusCode != http.StatusForbidden ||
+		problem["detail"] != "Your current balance is 30" ||
+		problem["balance"] != float64(30) {
+		t.Error("Error", resp.StatusCode, problem)
+	}
+	if _, ok := problem["message"]; ok {
+		t.Error("Error", problem)
+	}
+}
@@ -0,0 +1,74 @@
+package fibererror
+
+import (
+	"fmt"
+	"github.com/gofiber/fiber/v2"
+	"github.com/prongbang/goerror"
+	"runtime/debug"
+)
+
+// Panic configures the Recover middleware.
+type Panic struct {
+	// Error converts the recovered value into the error written, defaults to
+	// goerror.NewInternalServerError().
+	Error func(c *fiber.Ctx, r any) error
+	// StackTrace receives the recovered value and the stack trace of the
+	// panic, e.g. to log them.
+	StackTrace func(c *fiber.Ctx, r any, stack []byte)
+}
+
+// PanicError is the error written by Recover. It wraps the error converted
+// from the recovered value, so the response is written for that error.
+type PanicError struct {
+	Value any
+	Stack []byte
+	err   error
+}
+
+// Error implements error.
+func (p *PanicError) Error() string {
+	return fmt.Sprintf("panic: %v", p.Value)
+}
+
+// Unwrap returns the error converted from the recovered value.
+func (p *PanicError) Unwrap() error {
+	return p.err
+}
+
+// Recover returns a middleware that recovers from panics in the next
+// handlers and writes them through fibererror, by default as 500 Internal
+// Server Error:
+//
+//	app.Use(fibererror.Recover(&fibererror.Config{
+//		Panic: &fibererror.Panic{
+//			StackTrace: func(c *fiber.Ctx, r any, stack []byte) {
+//				log.Printf("panic: %v\n%s", r, stack)
+//			},
+//		},
+//	}))
+func Recover(cfg *Config) fiber.Handler {
+	resp := New(cfg)
+	p := &Panic{}
+	if cfg != nil && cfg.Panic != nil {
+		p = cfg.Panic
+	}
+	return func(c *fiber.Ctx) (err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				stack := debug.Stack()
+				if p.StackTrace != nil {
+					p.StackTrace(c, r, stack)
+				}
+				var e error
+				if p.Error != nil {
+					e = p.Error(c, r)
+				}
+				if e == nil {
+					e = goerror.NewInternalServerError()
+				}
+				err = resp.With(c).Response(&PanicError{Value: r, Stack: stack, err: e})
+			}
+		}()
+		return c.Next()
+	}
+}
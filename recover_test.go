@@ -0,0 +1,94 @@
+package fibererror_test
+
+import (
+	"errors"
+	"github.com/gofiber/fiber/v2"
+	"github.com/prongbang/fibererror"
+	"github.com/prongbang/goerror"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type CrashError struct {
+	goerror.Body
+}
+
+// Error implements error.
+func (p *CrashError) Error() string {
+	return p.Message
+}
+
+func TestRecover(t *testing.T) {
+	var (
+		recovered any
+		trace     []byte
+	)
+	app := fiber.New()
+	app.Use(fibererror.Recover(&fibererror.Config{
+		Panic: &fibererror.Panic{
+			StackTrace: func(c *fiber.Ctx, r any, stack []byte) {
+				recovered, trace = r, stack
+			},
+		},
+	}))
+	app.Get("/test", func(c *fiber.Ctx) error {
+		panic("boom")
+	})
+
+	resp, _ := app.Test(httptest.NewRequest("GET", "/test", nil))
+	body, _ := io.ReadAll(resp.Body)
+
+	if resp.StatusCode != http.StatusInternalServerError || !strings.Contains(string(body), goerror.CodeInternalServerError) {
+		t.Error("Error", resp.StatusCode, string(body))
+	}
+	if recovered != "boom" || !strings.Contains(string(trace), "recover_test.go") {
+		t.Error("Error", recovered, string(trace))
+	}
+}
+
+func TestRecoverError(t *testing.T) {
+	registry := fibererror.NewRegistry()
+	registry.Register(&CrashError{}, http.StatusServiceUnavailable)
+
+	app := fiber.New()
+	app.Use(fibererror.Recover(&fibererror.Config{
+		Registry: registry,
+		Panic: &fibererror.Panic{
+			Error: func(c *fiber.Ctx, r any) error {
+				return &CrashError{Body: goerror.Body{Code: "PNC001"}}
+			},
+		},
+	}))
+	app.Get("/test", func(c *fiber.Ctx) error {
+		panic(errors.New("boom"))
+	})
+
+	resp, _ := app.Test(httptest.NewRequest("GET", "/test", nil))
+	body, _ := io.ReadAll(resp.Body)
+
+	if resp.StatusCode != http.StatusServiceUnavailable || !strings.Contains(string(body), "PNC001") {
+		t.Error("Error", resp.StatusCode, string(body))
+	}
+}
+
+func TestRecoverI18n(t *testing.T) {
+	app := fiber.New()
+	app.Use(fibererror.Recover(&fibererror.Config{
+		I18n: localized,
+	}))
+	app.Get("/test", func(c *fiber.Ctx) error {
+		panic("boom")
+	})
+
+	req := httptest.NewRequest("GET", "/test", nil)
+	req.Header.Set(fiber.HeaderAcceptLanguage, "th")
+	resp, _ := app.Test(req)
+	body, _ := io.ReadAll(resp.Body)
+
+	if resp.StatusCode != http.StatusInternalServerError || !strings.Contains(string(body), "เกิดข้อผิดพลาดภายในเซิร์ฟเวอร์") {
+		t.Error("Error", resp.StatusCode, string(body))
+	}
+}
@@ -0,0 +1,100 @@
+package fibererror
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/prongbang/goerror"
+	"net/http"
+	"regexp"
+)
+
+// Patterns for Redact.Patterns.
+var (
+	// RedactEmail matches email addresses.
+	RedactEmail = regexp.MustCompile(`[A-Za-z0-9._%+\-]+@[A-Za-z0-9.\-]+\.[A-Za-z]{2,}`)
+	// RedactToken matches bearer tokens, JWTs and key=value secrets.
+	RedactToken = regexp.MustCompile(`(?i)bearer\s+[A-Za-z0-9\-._~+/]+=*|eyJ[A-Za-z0-9_\-]+\.[A-Za-z0-9_\-]+\.[A-Za-z0-9_\-]*|\b(?:token|api[_-]?key|secret|password)=\S+`)
+	// RedactSQL matches SQL statements and the rest of the message after them.
+	RedactSQL = regexp.MustCompile(`(?is)\b(?:SELECT\b.+?\bFROM|INSERT\s+INTO|UPDATE\b.+?\bSET|DELETE\s+FROM)\b.*`)
+)
+
+// Redact configures the redaction of written messages.
+type Redact struct {
+	// Status reports whether the message written with status is replaced,
+	// defaults to 5xx statuses.
+	Status func(status int) bool
+	// Codes lists the codes whose messages are replaced regardless of status.
+	Codes []string
+	// Message is the safe message. It defaults to the localized message of
+	// the code when I18n is enabled, or else the status text.
+	Message string
+	// Patterns are scrubbed from the messages that are not replaced.
+	Patterns []*regexp.Regexp
+	// Replacement replaces scrubbed matches, defaults to "[REDACTED]".
+	Replacement string
+	// OnRedact receives the original message of a replaced or scrubbed
+	// message, e.g. to log it.
+	OnRedact func(c *fiber.Ctx, message string, err error)
+}
+
+// redact returns target with its message replaced or scrubbed by Redact.
+// A status of 0 is not known yet, so the message is only scrubbed. target
+// itself is never modified.
+func (s *httpResponse) redact(status int, target error, err error) error {
+	if s.Redact == nil {
+		return target
+	}
+	body := bodyOf(target)
+	message := s.redactMessage(status, body, err)
+	if message == body.Message {
+		return target
+	}
+	s.onRedact(body.Message, err)
+	return withMessage(target, message)
+}
+
+// redactMessage returns the message of body written with status, replaced
+// or scrubbed by Redact.
+func (s *httpResponse) redactMessage(status int, body goerror.Body, err error) string {
+	if s.redacted(status, body.Code) {
+		if s.Redact.Message != "" {
+			return s.Redact.Message
+		}
+		if localize, ok := s.translate(body.Code, templateData(err)); ok {
+			return localize
+		}
+		return http.StatusText(status)
+	}
+	replacement := s.Redact.Replacement
+	if replacement == "" {
+		replacement = "[REDACTED]"
+	}
+	message := body.Message
+	for _, p := range s.Redact.Patterns {
+		message = p.ReplaceAllString(message, replacement)
+	}
+	return message
+}
+
+// onRedact calls Redact.OnRedact with the original message.
+func (s *httpResponse) onRedact(message string, err error) {
+	if s.Redact.OnRedact != nil {
+		s.Redact.OnRedact(s.Ctx, message, err)
+	}
+}
+
+// redacted reports whether the message written with status and code is
+// replaced.
+func (s *httpResponse) redacted(status int, code string) bool {
+	if status == 0 {
+		return false
+	}
+	for _, c := range s.Redact.Codes {
+		if c == code {
+			return true
+		}
+	}
+	if s.Redact.Status != nil {
+		return s.Redact.Status(status)
+	}
+	return status >= http.StatusInternalServerError
+}
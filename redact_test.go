@@ -0,0 +1,164 @@
+package fibererror_test
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/prongbang/fibererror"
+	"github.com/prongbang/goerror"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+type DatabaseError struct {
+	goerror.Body
+}
+
+// Error implements error.
+func (d *DatabaseError) Error() string {
+	return d.Message
+}
+
+func TestRedact(t *testing.T) {
+	var original string
+	registry := fibererror.NewRegistry()
+	registry.Register(&DatabaseError{}, http.StatusInternalServerError)
+
+	resp, body := respond(t, fibererror.New(&fibererror.Config{
+		Registry: registry,
+		Redact: &fibererror.Redact{
+			OnRedact: func(c *fiber.Ctx, message string, err error) {
+				original = message
+			},
+		},
+	}), &DatabaseError{Body: goerror.Body{Code: "DB001", Message: `pq: relation "users" does not exist`}}, nil)
+
+	if resp.StatusCode != http.StatusInternalServerError || !strings.Contains(body, `"code":"DB001","message":"Internal Server Error"`) {
+		t.Error("Error", resp.StatusCode, body)
+	}
+	if original != `pq: relation "users" does not exist` {
+		t.Error("Error", original)
+	}
+}
+
+func TestRedactLocalized(t *testing.T) {
+	req := httptest.NewRequest("GET", "/test", nil)
+	req.Header.Set(fiber.HeaderAcceptLanguage, "th")
+	_, body := respond(t, fibererror.New(&fibererror.Config{
+		I18n:   localized,
+		Redact: &fibererror.Redact{},
+	}), goerror.NewBadRequest("ignored"), req)
+
+	if !strings.Contains(body, "ignored") {
+		t.Error("Error", body)
+	}
+
+	req = httptest.NewRequest("GET", "/test", nil)
+	req.Header.Set(fiber.HeaderAcceptLanguage, "th")
+	_, body = respond(t, fibererror.New(&fibererror.Config{
+		I18n:     localized,
+		Redact:   &fibererror.Redact{},
+		Fallback: &fibererror.Fallback{Message: "dial tcp 10.0.0.1:5432: connection refused"},
+	}), io.EOF, req)
+
+	if !strings.Contains(body, "เกิดข้อผิดพลาดภายในเซิร์ฟเวอร์") || strings.Contains(body, "10.0.0.1") {
+		t.Error("Error", body)
+	}
+}
+
+func TestRedactCodes(t *testing.T) {
+	_, body := respond(t, fibererror.New(&fibererror.Config{
+		Redact: &fibererror.Redact{
+			Codes:   []string{goerror.CodeConflict},
+			Message: "Something went wrong",
+		},
+	}), goerror.NewBadRequest("duplicate key value violates unique constraint"), nil)
+
+	if !strings.Contains(body, "duplicate key") {
+		t.Error("Error", body)
+	}
+
+	conflict := goerror.NewConflict()
+	goerror.SetMessage(conflict, "duplicate key value violates unique constraint")
+	_, body = respond(t, fibererror.New(&fibererror.Config{
+		Redact: &fibererror.Redact{
+			Codes:   []string{goerror.CodeConflict},
+			Message: "Something went wrong",
+		},
+	}), conflict, nil)
+
+	if !strings.Contains(body, `"message":"Something went wrong"`) {
+		t.Error("Error", body)
+	}
+}
+
+func TestRedactPatterns(t *testing.T) {
+	_, body := respond(t, fibererror.New(&fibererror.Config{
+		Redact: &fibererror.Redact{
+			Patterns: []*regexp.Regexp{fibererror.RedactEmail, fibererror.RedactToken, fibererror.RedactSQL},
+		},
+	}), goerror.NewBadRequest("user john@example.com with Bearer abc.def rejected: SELECT * FROM users WHERE id = 1"), nil)
+
+	if !strings.Contains(body, `"message":"user [REDACTED] with [REDACTED] rejected: [REDACTED]"`) {
+		t.Error("Error", body)
+	}
+}
+
+type databaseResponse struct {
+	Status int
+	Calls  int
+}
+
+// Response implements fibererror.Custom.
+func (d *databaseResponse) Response(ctx *fiber.Ctx, err error) error {
+	if e, ok := err.(*DatabaseError); ok {
+		d.Calls++
+		return ctx.Status(d.Status).JSON(e)
+	}
+	return fibererror.ErrUnhandled
+}
+
+func TestRedactCustom(t *testing.T) {
+	var originals []string
+	handler := &databaseResponse{Status: http.StatusInternalServerError}
+	var custom fibererror.Custom = handler
+	res := fibererror.New(&fibererror.Config{
+		Custom: &custom,
+		Redact: &fibererror.Redact{
+			Patterns: []*regexp.Regexp{fibererror.RedactToken},
+			OnRedact: func(c *fiber.Ctx, message string, err error) {
+				originals = append(originals, message)
+			},
+		},
+	})
+
+	resp, body := respond(t, res, &DatabaseError{Body: goerror.Body{Code: "DB001", Message: "pq: password=hunter2 SELECT * FROM users"}}, nil)
+
+	if resp.StatusCode != http.StatusInternalServerError || body != `{"code":"DB001","message":"Internal Server Error","data":null}` {
+		t.Error("Error", resp.StatusCode, body)
+	}
+	if handler.Calls != 1 {
+		t.Error("Error", handler.Calls)
+	}
+	if len(originals) != 1 || originals[0] != "pq: password=hunter2 SELECT * FROM users" {
+		t.Error("Error", originals)
+	}
+}
+
+func TestRedactCustomPatterns(t *testing.T) {
+	var custom fibererror.Custom = &databaseResponse{Status: http.StatusBadRequest}
+	res := fibererror.New(&fibererror.Config{
+		Custom: &custom,
+		Redact: &fibererror.Redact{
+			Patterns: []*regexp.Regexp{fibererror.RedactToken},
+		},
+	})
+
+	resp, body := respond(t, res, &DatabaseError{Body: goerror.Body{Code: "DB002", Message: "login failed: password=hunter2"}}, nil)
+
+	if resp.StatusCode != http.StatusBadRequest || body != `{"code":"DB002","message":"login failed: [REDACTED]","data":null}` {
+		t.Error("Error", resp.StatusCode, body)
+	}
+}
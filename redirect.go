@@ -0,0 +1,139 @@
+package fibererror
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"html"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// RedirectBody is the body written with a redirect.
+type RedirectBody int
+
+const (
+	// RedirectJSON writes the error as for any other status.
+	RedirectJSON RedirectBody = iota
+	// RedirectEmpty writes no body.
+	RedirectEmpty
+	// RedirectHTML writes an HTML link to the location.
+	RedirectHTML
+)
+
+// Redirect configures the responses of 3xx errors carrying a location.
+type Redirect struct {
+	Body RedirectBody
+	// AllowedHosts are the hosts, other than the host of the request, an
+	// absolute location can point to.
+	AllowedHosts []string
+	// Fallback replaces a location that is not allowed, defaults to "/".
+	Fallback string
+}
+
+type location string
+
+// WithLocation wraps a 3xx error with the URL written as its Location header:
+//
+//	response.With(c).Response(fibererror.WithLocation(goerror.NewSeeOther(), "/orders/42"))
+//	// 303 Location: /orders/42
+//
+// Relative locations are always allowed. Absolute ones must point to the
+// host of the request or one of Redirect.AllowedHosts, or Redirect.Fallback
+// is written instead, so a location taken from the request cannot redirect
+// to a foreign host.
+func WithLocation(err error, to string) error {
+	return annotate(err, location(to))
+}
+
+// redirectStatus reports whether status is a redirect that uses Location.
+func redirectStatus(status int) bool {
+	switch status {
+	case http.StatusMultipleChoices, http.StatusMovedPermanently, http.StatusFound,
+		http.StatusSeeOther, http.StatusTemporaryRedirect, http.StatusPermanentRedirect:
+		return true
+	}
+	return false
+}
+
+// redirect writes the Location header of a redirect status from the first
+// location in the chain of err, and reports whether it did.
+func (s *httpResponse) redirect(status int, err error) bool {
+	if !redirectStatus(status) {
+		return false
+	}
+	to, ok := lookup(err, func(v any) (string, bool) {
+		switch t := v.(type) {
+		case location:
+			return string(t), true
+		case interface{ Location() string }:
+			return t.Location(), true
+		}
+		return "", false
+	})
+	if !ok {
+		return false
+	}
+	to = strings.TrimSpace(to)
+	if !s.allowed(to) {
+		to = "/"
+		if s.Redirect != nil && s.Redirect.Fallback != "" {
+			to = s.Redirect.Fallback
+		}
+	}
+	s.Ctx.Set(fiber.HeaderLocation, to)
+	return true
+}
+
+// allowed reports whether to is relative to the host, or points to the host
+// of the request or an allowed host over HTTP(S). Whitespace, control
+// characters and backslashes are rejected, as browsers strip them or read a
+// backslash as a slash, so "/\evil.com" or "/\t/evil.com" would reach a
+// foreign host.
+func (s *httpResponse) allowed(to string) bool {
+	for _, r := range to {
+		if r <= ' ' || r == 0x7f || r == '\\' {
+			return false
+		}
+	}
+	u, err := url.Parse(to)
+	if err != nil {
+		return false
+	}
+	if u.Scheme == "" && u.Host == "" {
+		// "//host" is relative to the scheme only.
+		return !strings.HasPrefix(to, "//")
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return false
+	}
+	if strings.EqualFold(u.Host, s.Ctx.Hostname()) {
+		return true
+	}
+	if s.Redirect != nil {
+		for _, host := range s.Redirect.AllowedHosts {
+			if strings.EqualFold(u.Host, host) || strings.EqualFold(u.Hostname(), host) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// redirectBody writes the body of a redirect with Redirect.Body, and reports
+// whether it did.
+func (s *httpResponse) redirectBody(status int) (bool, error) {
+	if s.Redirect == nil {
+		return false, nil
+	}
+	switch s.Redirect.Body {
+	case RedirectEmpty:
+		s.strip(status)
+		return true, nil
+	case RedirectHTML:
+		location := html.EscapeString(s.Ctx.GetRespHeader(fiber.HeaderLocation))
+		s.Ctx.Set(fiber.HeaderContentType, fiber.MIMETextHTMLCharsetUTF8)
+		return true, s.Ctx.Status(status).SendString(`<a href="` + location + `">` +
+			html.EscapeString(http.StatusText(status)) + "</a>.\n")
+	}
+	return false, nil
+}
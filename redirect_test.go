@@ -0,0 +1,77 @@
+package fibererror_test
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/prongbang/fibererror"
+	"github.com/prongbang/goerror"
+	"net/http"
+	"testing"
+)
+
+func TestWithLocation(t *testing.T) {
+	resp, body := respond(t, response, fibererror.WithLocation(goerror.NewSeeOther(), "/orders/42"), nil)
+
+	expected := `{"code":"RED003","message":"See Other","data":null}`
+	if resp.StatusCode != http.StatusSeeOther || resp.Header.Get(fiber.HeaderLocation) != "/orders/42" || body != expected {
+		t.Error("Error", resp.StatusCode, resp.Header, body)
+	}
+}
+
+func TestWithLocationEmpty(t *testing.T) {
+	resp, body := respond(t, fibererror.New(&fibererror.Config{
+		Redirect: &fibererror.Redirect{Body: fibererror.RedirectEmpty},
+	}), fibererror.WithLocation(goerror.NewFound(), "https://example.com/login"), nil)
+
+	if resp.StatusCode != http.StatusFound || resp.Header.Get(fiber.HeaderLocation) != "https://example.com/login" || body != "" {
+		t.Error("Error", resp.StatusCode, resp.Header, body)
+	}
+}
+
+func TestWithLocationHTML(t *testing.T) {
+	resp, body := respond(t, fibererror.New(&fibererror.Config{
+		Redirect: &fibererror.Redirect{Body: fibererror.RedirectHTML},
+	}), fibererror.WithLocation(goerror.NewMovedPermanently(), "/search?q=a&b=<c>"), nil)
+
+	expected := `<a href="/search?q=a&amp;b=&lt;c&gt;">Moved Permanently</a>.` + "\n"
+	if resp.StatusCode != http.StatusMovedPermanently || resp.Header.Get(fiber.HeaderContentType) != fiber.MIMETextHTMLCharsetUTF8 || body != expected {
+		t.Error("Error", resp.StatusCode, resp.Header, body)
+	}
+}
+
+func TestWithLocationForeignHost(t *testing.T) {
+	locations := map[string]string{
+		"https://evil.com/login":   "/home",
+		"//evil.com/login":         "/home",
+		"/\\evil.com":              "/home",
+		"javascript:alert(1)":      "/home",
+		" //evil.com":              "/home",
+		"\t//evil.com":             "/home",
+		"//evil.com ":              "/home",
+		"/\t/evil.com":             "/home",
+		"/\r\n/evil.com":           "/home",
+		"\\\\evil.com":             "/home",
+		"https:evil.com":           "/home",
+		"https://auth.example.org": "https://auth.example.org",
+		" /orders/42 ":             "/orders/42",
+	}
+	for location, expected := range locations {
+		resp, _ := respond(t, fibererror.New(&fibererror.Config{
+			Redirect: &fibererror.Redirect{
+				AllowedHosts: []string{"auth.example.org"},
+				Fallback:     "/home",
+			},
+		}), fibererror.WithLocation(goerror.NewTemporaryRedirect(), location), nil)
+
+		if resp.Header.Get(fiber.HeaderLocation) != expected {
+			t.Error("Error", location, resp.Header.Get(fiber.HeaderLocation))
+		}
+	}
+}
+
+func TestWithLocationNotRedirect(t *testing.T) {
+	resp, _ := respond(t, response, fibererror.WithLocation(goerror.NewNotFound(), "/orders"), nil)
+
+	if resp.StatusCode != http.StatusNotFound || resp.Header.Get(fiber.HeaderLocation) != "" {
+		t.Error("Error", resp.StatusCode, resp.Header)
+	}
+}
@@ -0,0 +1,214 @@
+package fibererror
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/prongbang/goerror"
+	"net/http"
+	"reflect"
+	"sync"
+)
+
+// Render writes the response for an error matched by a Registry.
+type Render func(ctx *fiber.Ctx, status int, err error) error
+
+// Mapping is the status code and optional renderer registered for an error.
+// A nil Render writes the error as JSON.
+type Mapping struct {
+	Status int
+	Render Render
+}
+
+// Translator converts an error of another package, such as a validation
+// library, into an error known to the Registry, or returns nil.
+type Translator func(err error) error
+
+// Registry maps errors to HTTP status codes. Types are matched exactly and
+// take precedence over predicates, which are tried in registration order.
+// Errors are translated before they are looked up.
+type Registry interface {
+	Register(target error, status int, render ...Render)
+	RegisterFunc(match func(err error) bool, status int, render ...Render)
+	RegisterTranslator(translate Translator)
+	Lookup(err error) (Mapping, bool)
+	Translate(err error) error
+}
+
+type matcher struct {
+	Match   func(err error) bool
+	Mapping Mapping
+}
+
+type registry struct {
+	mu          sync.RWMutex
+	types       map[reflect.Type]Mapping
+	funcs       []matcher
+	translators []Translator
+}
+
+// Register implements Registry.
+//
+// Registering a type again replaces its mapping, so the goerror defaults can
+// be overridden per application:
+//
+//	registry.Register(&goerror.BadRequest{}, http.StatusUnprocessableEntity)
+func (r *registry) Register(target error, status int, render ...Render) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.types[reflect.TypeOf(target)] = mapping(status, render)
+}
+
+// RegisterFunc implements Registry.
+func (r *registry) RegisterFunc(match func(err error) bool, status int, render ...Render) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.funcs = append(r.funcs, matcher{Match: match, Mapping: mapping(status, render)})
+}
+
+// RegisterTranslator implements Registry.
+func (r *registry) RegisterTranslator(translate Translator) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.translators = append(r.translators, translate)
+}
+
+// Translate implements Registry.
+//
+// The first translator returning an error wins, nil means err is not
+// translated.
+func (r *registry) Translate(err error) error {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for _, translate := range r.translators {
+		if e := translate(err); e != nil {
+			return e
+		}
+	}
+	return nil
+}
+
+// Lookup implements Registry.
+func (r *registry) Lookup(err error) (Mapping, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if m, ok := r.types[reflect.TypeOf(err)]; ok {
+		return m, true
+	}
+	for _, f := range r.funcs {
+		if f.Match(err) {
+			return f.Mapping, true
+		}
+	}
+	return Mapping{}, false
+}
+
+func mapping(status int, render []Render) Mapping {
+	m := Mapping{Status: status}
+	if len(render) > 0 {
+		m.Render = render[0]
+	}
+	return m
+}
+
+// defaults are the goerror types, and ClientClosedRequest, registered by
+// NewRegistry.
+var defaults = []struct {
+	Status int
+	New    func() error
+}{
+	// Information
+	{http.StatusContinue, goerror.NewContinue},
+	{http.StatusSwitchingProtocols, goerror.NewSwitchingProtocols},
+	{http.StatusProcessing, goerror.NewProcessing},
+	{http.StatusEarlyHints, goerror.NewEarlyHints},
+
+	// Successful
+	{http.StatusOK, func() error { return goerror.NewOK(nil) }},
+	{http.StatusCreated, func() error { return goerror.NewCreated(nil) }},
+	{http.StatusAccepted, goerror.NewAccepted},
+	{http.StatusNonAuthoritativeInfo, goerror.NewNonAuthoritativeInformation},
+	{http.StatusNoContent, goerror.NewNoContent},
+	{http.StatusResetContent, goerror.NewResetContent},
+	{http.StatusPartialContent, goerror.NewPartialContent},
+	{http.StatusMultiStatus, goerror.NewMultiStatus},
+	{http.StatusAlreadyReported, goerror.NewAlreadyReported},
+	{http.StatusIMUsed, goerror.NewIMUsed},
+
+	// Redirection
+	{http.StatusMultipleChoices, goerror.NewMultipleChoices},
+	{http.StatusMovedPermanently, goerror.NewMovedPermanently},
+	{http.StatusFound, goerror.NewFound},
+	{http.StatusSeeOther, goerror.NewSeeOther},
+	{http.StatusNotModified, goerror.NewNotModified},
+	{http.StatusUseProxy, goerror.NewUseProxy},
+	{http.StatusTemporaryRedirect, goerror.NewTemporaryRedirect},
+	{http.StatusPermanentRedirect, goerror.NewPermanentRedirect},
+
+	// Client error
+	{http.StatusBadRequest, func() error { return goerror.NewBadRequest() }},
+	{http.StatusUnauthorized, goerror.NewUnauthorized},
+	{http.StatusPaymentRequired, goerror.NewPaymentRequired},
+	{http.StatusForbidden, goerror.NewForbidden},
+	{http.StatusNotFound, goerror.NewNotFound},
+	{http.StatusMethodNotAllowed, goerror.NewMethodNotAllowed},
+	{http.StatusNotAcceptable, goerror.NewNotAcceptable},
+	{http.StatusProxyAuthRequired, goerror.NewProxyAuthRequired},
+	{http.StatusRequestTimeout, goerror.NewRequestTimeout},
+	{http.StatusConflict, goerror.NewConflict},
+	{http.StatusGone, goerror.NewGone},
+	{http.StatusLengthRequired, goerror.NewLengthRequired},
+	{http.StatusPreconditionFailed, goerror.NewPreconditionFailed},
+	{http.StatusRequestEntityTooLarge, goerror.NewRequestEntityTooLarge},
+	{http.StatusRequestURITooLong, goerror.NewRequestURITooLong},
+	{http.StatusUnsupportedMediaType, goerror.NewUnsupportedMediaType},
+	{http.StatusRequestedRangeNotSatisfiable, goerror.NewRequestedRangeNotSatisfiable},
+	{http.StatusExpectationFailed, goerror.NewExpectationFailed},
+	{http.StatusTeapot, goerror.NewTeapot},
+	{http.StatusMisdirectedRequest, goerror.NewMisdirectedRequest},
+	{http.StatusUnprocessableEntity, goerror.NewUnprocessableEntity},
+	{http.StatusLocked, goerror.NewLocked},
+	{http.StatusFailedDependency, goerror.NewFailedDependency},
+	{http.StatusTooEarly, goerror.NewTooEarly},
+	{http.StatusUpgradeRequired, goerror.NewUpgradeRequired},
+	{http.StatusPreconditionRequired, goerror.NewPreconditionRequired},
+	{http.StatusTooManyRequests, goerror.NewTooManyRequests},
+	{http.StatusRequestHeaderFieldsTooLarge, goerror.NewRequestHeaderFieldsTooLarge},
+	{http.StatusUnavailableForLegalReasons, goerror.NewUnavailableForLegalReasons},
+	{StatusClientClosedRequest, NewClientClosedRequest},
+
+	// Server error
+	{http.StatusInternalServerError, goerror.NewInternalServerError},
+	{http.StatusNotImplemented, goerror.NewNotImplemented},
+	{http.StatusBadGateway, goerror.NewBadGateway},
+	{http.StatusServiceUnavailable, goerror.NewServiceUnavailable},
+	{http.StatusGatewayTimeout, goerror.NewGatewayTimeout},
+	{http.StatusHTTPVersionNotSupported, goerror.NewHTTPVersionNotSupported},
+	{http.StatusVariantAlsoNegotiates, goerror.NewVariantAlsoNegotiates},
+	{http.StatusInsufficientStorage, goerror.NewInsufficientStorage},
+	{http.StatusLoopDetected, goerror.NewLoopDetected},
+	{http.StatusNotExtended, goerror.NewNotExtended},
+	{http.StatusNetworkAuthenticationRequired, goerror.NewNetworkAuthenticationRequired},
+}
+
+// NewRegistry returns a Registry with every goerror type mapped to its
+// HTTP status code, and the errors of BodyParser translated into them.
+func NewRegistry() Registry {
+	r := &registry{
+		types: make(map[reflect.Type]Mapping, len(defaults)),
+	}
+	for _, d := range defaults {
+		r.Register(d.New(), d.Status)
+	}
+	r.RegisterTranslator(translateBodyParser)
+	return r
+}
+
+// statusError returns a new goerror for the status code, or nil when there is
+// no goerror type for it.
+func statusError(status int) error {
+	for _, d := range defaults {
+		if d.Status == status {
+			return d.New()
+		}
+	}
+	return nil
+}
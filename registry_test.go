@@ -0,0 +1,89 @@
+package fibererror_test
+
+import (
+	"errors"
+	"fmt"
+	"github.com/gofiber/fiber/v2"
+	"github.com/prongbang/fibererror"
+	"github.com/prongbang/goerror"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+var errMaintenance = errors.New("maintenance")
+
+func TestRegistryOverride(t *testing.T) {
+	app := fiber.New()
+
+	registry := fibererror.NewRegistry()
+	registry.Register(&goerror.BadRequest{}, http.StatusUnprocessableEntity)
+	res := fibererror.New(&fibererror.Config{
+		Registry: registry,
+	})
+
+	app.Get("/test", func(c *fiber.Ctx) error {
+		return res.With(c).Response(goerror.NewBadRequest())
+	})
+
+	resp, _ := app.Test(httptest.NewRequest("GET", "/test", nil))
+
+	if resp.StatusCode != http.StatusUnprocessableEntity {
+		t.Error("Error", resp.StatusCode)
+	}
+}
+
+func TestRegistryOverrideIsolated(t *testing.T) {
+	registry := fibererror.NewRegistry()
+	registry.Register(&goerror.BadRequest{}, http.StatusUnprocessableEntity)
+
+	m, ok := fibererror.NewRegistry().Lookup(goerror.NewBadRequest())
+
+	if !ok || m.Status != http.StatusBadRequest {
+		t.Error("Error", m.Status)
+	}
+}
+
+func TestRegistryFunc(t *testing.T) {
+	app := fiber.New()
+
+	registry := fibererror.NewRegistry()
+	registry.RegisterFunc(func(err error) bool {
+		return err == errMaintenance
+	}, http.StatusServiceUnavailable)
+	res := fibererror.New(&fibererror.Config{
+		Registry: registry,
+	})
+
+	app.Get("/test", func(c *fiber.Ctx) error {
+		return res.With(c).Response(fmt.Errorf("sync: %w", errMaintenance))
+	})
+
+	resp, _ := app.Test(httptest.NewRequest("GET", "/test", nil))
+
+	if resp.StatusCode != http.StatusServiceUnavailable {
+		t.Error("Error", resp.StatusCode)
+	}
+}
+
+func TestRegistryRender(t *testing.T) {
+	app := fiber.New()
+
+	registry := fibererror.NewRegistry()
+	registry.Register(&CustomError{}, http.StatusTeapot, func(ctx *fiber.Ctx, status int, err error) error {
+		return ctx.Status(status).SendString(err.(*CustomError).Code)
+	})
+	res := fibererror.New(&fibererror.Config{
+		Registry: registry,
+	})
+
+	app.Get("/test", func(c *fiber.Ctx) error {
+		return res.With(c).Response(NewCustomError())
+	})
+
+	resp, _ := app.Test(httptest.NewRequest("GET", "/test", nil))
+
+	if resp.StatusCode != http.StatusTeapot {
+		t.Error("Error", resp.StatusCode)
+	}
+}
@@ -0,0 +1,383 @@
+package fibererror
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"github.com/gofiber/fiber/v2"
+	"github.com/vmihailenco/msgpack/v5"
+	"strings"
+	"unicode"
+)
+
+const (
+	// MIMEApplicationProblemXML is the XML media type of RFC 9457 problem details.
+	MIMEApplicationProblemXML = "application/problem+xml"
+	// MIMEApplicationMsgPack is the media type of MessagePack.
+	MIMEApplicationMsgPack = "application/msgpack"
+)
+
+// problemNamespace is the XML namespace of RFC 9457 problem details.
+const problemNamespace = "urn:ietf:rfc:7807"
+
+// Renderer encodes an error body for one media type. The body is the error
+// itself or a ProblemDetails, and is encoded by its JSON field names.
+type Renderer interface {
+	MediaType() string
+	Render(c *fiber.Ctx, body any) error
+}
+
+type jsonRenderer struct{}
+
+// MediaType implements Renderer.
+func (jsonRenderer) MediaType() string {
+	return fiber.MIMEApplicationJSON
+}
+
+// Render implements Renderer.
+func (jsonRenderer) Render(c *fiber.Ctx, body any) error {
+	if _, ok := body.(ProblemDetails); ok {
+		return c.JSON(body, MIMEApplicationProblemJSON)
+	}
+	return c.JSON(body)
+}
+
+// NewJSONRenderer returns a Renderer for application/json.
+func NewJSONRenderer() Renderer {
+	return jsonRenderer{}
+}
+
+type xmlRenderer struct{}
+
+// MediaType implements Renderer.
+func (xmlRenderer) MediaType() string {
+	return fiber.MIMEApplicationXML
+}
+
+// Render implements Renderer.
+//
+// The body is written as an <error> element, or a <problem> element in the
+// RFC 9457 namespace, with one child element per field.
+func (xmlRenderer) Render(c *fiber.Ctx, body any) error {
+	root := xml.StartElement{Name: xml.Name{Local: "error"}}
+	ctype := fiber.MIMEApplicationXMLCharsetUTF8
+	if _, ok := body.(ProblemDetails); ok {
+		root.Name = xml.Name{Space: problemNamespace, Local: "problem"}
+		ctype = MIMEApplicationProblemXML
+	}
+	raw, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	buf := bytes.NewBufferString(xml.Header)
+	enc := xml.NewEncoder(buf)
+	if err = encodeXML(enc, root, raw); err != nil {
+		return err
+	}
+	if err = enc.Flush(); err != nil {
+		return err
+	}
+	c.Set(fiber.HeaderContentType, ctype)
+	return c.Send(buf.Bytes())
+}
+
+// NewXMLRenderer returns a Renderer for application/xml.
+func NewXMLRenderer() Renderer {
+	return xmlRenderer{}
+}
+
+type msgpackRenderer struct{}
+
+// MediaType implements Renderer.
+func (msgpackRenderer) MediaType() string {
+	return MIMEApplicationMsgPack
+}
+
+// Render implements Renderer.
+func (msgpackRenderer) Render(c *fiber.Ctx, body any) error {
+	raw, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	var v any
+	if err = json.Unmarshal(raw, &v); err != nil {
+		return err
+	}
+	b, err := msgpack.Marshal(v)
+	if err != nil {
+		return err
+	}
+	c.Set(fiber.HeaderContentType, MIMEApplicationMsgPack)
+	return c.Send(b)
+}
+
+// NewMsgPackRenderer returns a Renderer for application/msgpack.
+func NewMsgPackRenderer() Renderer {
+	return msgpackRenderer{}
+}
+
+type textRenderer struct{}
+
+// MediaType implements Renderer.
+func (textRenderer) MediaType() string {
+	return fiber.MIMETextPlain
+}
+
+// Render implements Renderer.
+//
+// The body is written as one "name: value" line per field.
+func (textRenderer) Render(c *fiber.Ctx, body any) error {
+	raw, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	members, err := membersOf(raw)
+	if err != nil {
+		return err
+	}
+	var sb strings.Builder
+	for _, m := range members {
+		if string(m.Value) == "null" {
+			continue
+		}
+		var s string
+		if json.Unmarshal(m.Value, &s) != nil {
+			s = string(m.Value)
+		}
+		sb.WriteString(m.Name + ": " + s + "\n")
+	}
+	c.Set(fiber.HeaderContentType, fiber.MIMETextPlainCharsetUTF8)
+	return c.SendString(sb.String())
+}
+
+// NewTextRenderer returns a Renderer for text/plain.
+func NewTextRenderer() Renderer {
+	return textRenderer{}
+}
+
+// renderer returns the Renderer accepted by the client, or the first one
+// when none is acceptable, so the error is never answered with 406.
+func (s *httpResponse) renderer() Renderer {
+	if len(s.Renderers) == 0 {
+		return jsonRenderer{}
+	}
+	offers := make([]string, len(s.Renderers))
+	for i, r := range s.Renderers {
+		offers[i] = r.MediaType()
+	}
+	if accepted := s.Ctx.Accepts(offers...); accepted != "" {
+		for _, r := range s.Renderers {
+			if r.MediaType() == accepted {
+				return r
+			}
+		}
+	}
+	return s.Renderers[0]
+}
+
+// field is a member added to a written body.
+type field struct {
+	Name  string
+	Value any
+}
+
+// extendedBody encodes body followed by fields, which replace members of
+// body with the same name.
+type extendedBody struct {
+	body   any
+	fields []field
+}
+
+// MarshalJSON implements json.Marshaler.
+func (e extendedBody) MarshalJSON() ([]byte, error) {
+	raw, err := json.Marshal(e.body)
+	if err != nil {
+		return nil, err
+	}
+	members, err := membersOf(raw)
+	if err != nil {
+		return raw, nil
+	}
+	replaced := make(map[string]bool, len(e.fields))
+	for _, f := range e.fields {
+		replaced[f.Name] = true
+	}
+	buf := bytes.NewBufferString("{")
+	for _, m := range members {
+		if replaced[m.Name] {
+			continue
+		}
+		if buf.Len() > 1 {
+			buf.WriteByte(',')
+		}
+		k, _ := json.Marshal(m.Name)
+		buf.Write(k)
+		buf.WriteByte(':')
+		buf.Write(m.Value)
+	}
+	for _, f := range e.fields {
+		v, err := json.Marshal(f.Value)
+		if err != nil {
+			return nil, err
+		}
+		if buf.Len() > 1 {
+			buf.WriteByte(',')
+		}
+		k, _ := json.Marshal(f.Name)
+		buf.Write(k)
+		buf.WriteByte(':')
+		buf.Write(v)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// inject adds fields to a JSON object body written by a Custom handler or
+// Fallback.Response, as they are added to the bodies written by Response.
+func (s *httpResponse) inject(fields []field) {
+	res := s.Ctx.Response()
+	if len(fields) == 0 || !strings.Contains(string(res.Header.ContentType()), "json") {
+		return
+	}
+	body := append([]byte(nil), res.Body()...)
+	if _, err := membersOf(body); err != nil {
+		return
+	}
+	b, err := json.Marshal(extendedBody{body: json.RawMessage(body), fields: fields})
+	if err == nil {
+		res.SetBodyRaw(b)
+	}
+}
+
+// rewrite replaces the message member of a JSON object body written by a
+// Custom handler, keeping the order of its members.
+func (s *httpResponse) rewrite(message string) {
+	res := s.Ctx.Response()
+	if !strings.Contains(string(res.Header.ContentType()), "json") {
+		return
+	}
+	members, err := membersOf(res.Body())
+	if err != nil {
+		return
+	}
+	value, _ := json.Marshal(message)
+	buf := bytes.NewBufferString("{")
+	for i, m := range members {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		k, _ := json.Marshal(m.Name)
+		buf.Write(k)
+		buf.WriteByte(':')
+		if m.Name == "message" {
+			buf.Write(value)
+		} else {
+			buf.Write(m.Value)
+		}
+	}
+	buf.WriteByte('}')
+	res.SetBodyRaw(buf.Bytes())
+}
+
+type member struct {
+	Name  string
+	Value json.RawMessage
+}
+
+// membersOf returns the members of a JSON object in document order.
+func membersOf(raw []byte) ([]member, error) {
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	if tok != json.Delim('{') {
+		return nil, errors.New("body is not a JSON object")
+	}
+	var members []member
+	for dec.More() {
+		tok, err = dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		m := member{Name: tok.(string)}
+		if err = dec.Decode(&m.Value); err != nil {
+			return nil, err
+		}
+		members = append(members, m)
+	}
+	return members, nil
+}
+
+// encodeXML writes the JSON value raw as the element start. Objects become
+// child elements, arrays repeated <item> elements and null is omitted.
+func encodeXML(enc *xml.Encoder, start xml.StartElement, raw json.RawMessage) error {
+	raw = bytes.TrimSpace(raw)
+	if len(raw) == 0 || string(raw) == "null" {
+		return nil
+	}
+	if err := enc.EncodeToken(start); err != nil {
+		return err
+	}
+	switch raw[0] {
+	case '{':
+		members, err := membersOf(raw)
+		if err != nil {
+			return err
+		}
+		for _, m := range members {
+			if err = encodeXML(enc, xmlElement(m.Name), m.Value); err != nil {
+				return err
+			}
+		}
+	case '[':
+		var items []json.RawMessage
+		if err := json.Unmarshal(raw, &items); err != nil {
+			return err
+		}
+		for _, item := range items {
+			if err := encodeXML(enc, xml.StartElement{Name: xml.Name{Local: "item"}}, item); err != nil {
+				return err
+			}
+		}
+	default:
+		var s string
+		if json.Unmarshal(raw, &s) != nil {
+			s = string(raw)
+		}
+		if err := enc.EncodeToken(xml.CharData(s)); err != nil {
+			return err
+		}
+	}
+	return enc.EncodeToken(start.End())
+}
+
+// xmlElement returns the element of a JSON member. A name that is not a valid
+// XML name, such as "user id" or "1x", is written as <item name="user id">.
+func xmlElement(name string) xml.StartElement {
+	if xmlName(name) {
+		return xml.StartElement{Name: xml.Name{Local: name}}
+	}
+	return xml.StartElement{
+		Name: xml.Name{Local: "item"},
+		Attr: []xml.Attr{{Name: xml.Name{Local: "name"}, Value: name}},
+	}
+}
+
+// xmlName reports whether name is an XML name without a namespace prefix,
+// and not reserved by starting with "xml".
+func xmlName(name string) bool {
+	if name == "" || strings.HasPrefix(strings.ToLower(name), "xml") {
+		return false
+	}
+	for i, r := range name {
+		switch {
+		case r == '_' || unicode.IsLetter(r):
+		case i > 0 && (r == '-' || r == '.' || unicode.IsDigit(r)):
+		default:
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,114 @@
+package fibererror_test
+
+import (
+	"encoding/xml"
+	"github.com/gofiber/fiber/v2"
+	"github.com/prongbang/fibererror"
+	"github.com/prongbang/goerror"
+	"github.com/vmihailenco/msgpack/v5"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+var negotiated = fibererror.New(&fibererror.Config{
+	Renderers: []fibererror.Renderer{
+		fibererror.NewJSONRenderer(),
+		fibererror.NewXMLRenderer(),
+		fibererror.NewMsgPackRenderer(),
+		fibererror.NewTextRenderer(),
+	},
+})
+
+func TestRenderXML(t *testing.T) {
+	req := httptest.NewRequest("GET", "/test", nil)
+	req.Header.Set(fiber.HeaderAccept, "application/xml")
+	resp, body := respond(t, negotiated, goerror.NewNotFound(), req)
+
+	if resp.StatusCode != http.StatusNotFound ||
+		!strings.HasPrefix(resp.Header.Get(fiber.HeaderContentType), fiber.MIMEApplicationXML) ||
+		!strings.Contains(body, "<error><code>CLE004</code><message>Not Found</message></error>") {
+		t.Error("Error", resp.StatusCode, body)
+	}
+}
+
+func TestRenderText(t *testing.T) {
+	req := httptest.NewRequest("GET", "/test", nil)
+	req.Header.Set(fiber.HeaderAccept, "text/plain")
+	resp, body := respond(t, negotiated, goerror.NewNotFound(), req)
+
+	if resp.StatusCode != http.StatusNotFound || body != "code: CLE004\nmessage: Not Found\n" {
+		t.Error("Error", resp.StatusCode, body)
+	}
+}
+
+func TestRenderMsgPack(t *testing.T) {
+	req := httptest.NewRequest("GET", "/test", nil)
+	req.Header.Set(fiber.HeaderAccept, fibererror.MIMEApplicationMsgPack)
+	resp, body := respond(t, negotiated, goerror.NewNotFound(), req)
+
+	var v map[string]any
+	err := msgpack.Unmarshal([]byte(body), &v)
+
+	if err != nil || resp.StatusCode != http.StatusNotFound || v["code"] != goerror.CodeNotFound {
+		t.Error("Error", resp.StatusCode, err, v)
+	}
+}
+
+func TestRenderDefault(t *testing.T) {
+	req := httptest.NewRequest("GET", "/test", nil)
+	resp, body := respond(t, negotiated, goerror.NewNotFound(), req)
+
+	if !strings.HasPrefix(resp.Header.Get(fiber.HeaderContentType), fiber.MIMEApplicationJSON) ||
+		!strings.Contains(body, `"code":"CLE004"`) {
+		t.Error("Error", resp.StatusCode, body)
+	}
+}
+
+func TestRenderNotAcceptable(t *testing.T) {
+	req := httptest.NewRequest("GET", "/test", nil)
+	req.Header.Set(fiber.HeaderAccept, "image/png")
+	resp, body := respond(t, negotiated, goerror.NewNotFound(), req)
+
+	if resp.StatusCode != http.StatusNotFound || !strings.Contains(body, `"code":"CLE004"`) {
+		t.Error("Error", resp.StatusCode, body)
+	}
+}
+
+func TestRenderProblemXML(t *testing.T) {
+	res := fibererror.New(&fibererror.Config{
+		Format: fibererror.FormatProblem,
+		Renderers: []fibererror.Renderer{
+			fibererror.NewJSONRenderer(),
+			fibererror.NewXMLRenderer(),
+		},
+	})
+
+	req := httptest.NewRequest("GET", "/test", nil)
+	req.Header.Set(fiber.HeaderAccept, "application/xml")
+	resp, body := respond(t, res, goerror.NewNotFound(), req)
+
+	if resp.Header.Get(fiber.HeaderContentType) != fibererror.MIMEApplicationProblemXML ||
+		!strings.Contains(body, `<problem xmlns="urn:ietf:rfc:7807"><type>about:blank</type>`) {
+		t.Error("Error", resp.StatusCode, body)
+	}
+}
+
+func TestRenderXMLInvalidNames(t *testing.T) {
+	err := &goerror.BadRequest{Body: goerror.Body{
+		Code:    goerror.CodeBadRequest,
+		Message: "Bad Request",
+		Data:    map[string]any{"user id": 1, "1x": 2, "xmlns": 3, "name": "john"},
+	}}
+
+	req := httptest.NewRequest("GET", "/test", nil)
+	req.Header.Set(fiber.HeaderAccept, fiber.MIMEApplicationXML)
+	_, body := respond(t, negotiated, err, req)
+
+	expected := `<data><item name="1x">2</item><name>john</name><item name="user id">1</item><item name="xmlns">3</item></data>`
+	var v struct{}
+	if !strings.Contains(body, expected) || xml.Unmarshal([]byte(body), &v) != nil {
+		t.Error("Error", body)
+	}
+}
@@ -0,0 +1,54 @@
+package fibererror
+
+import "github.com/gofiber/fiber/v2"
+
+// RequestID configures the request ID written with every error response.
+type RequestID struct {
+	// Local is the Locals key of the request ID, defaults to "requestid" as
+	// set by the requestid middleware.
+	Local string
+	// Header is read from the request when Local is not set, and written to
+	// the response, defaults to X-Request-ID.
+	Header string
+	// Field is the body member, defaults to "request_id".
+	Field string
+}
+
+func (r *RequestID) local() string {
+	if r.Local == "" {
+		return "requestid"
+	}
+	return r.Local
+}
+
+func (r *RequestID) header() string {
+	if r.Header == "" {
+		return fiber.HeaderXRequestID
+	}
+	return r.Header
+}
+
+func (r *RequestID) field() string {
+	if r.Field == "" {
+		return "request_id"
+	}
+	return r.Field
+}
+
+// requestID returns the request ID and writes it to the response header.
+func (s *httpResponse) requestID() string {
+	if s.RequestID == nil {
+		return ""
+	}
+	id, _ := s.Ctx.Locals(s.RequestID.local()).(string)
+	if id == "" {
+		id = s.Ctx.Get(s.RequestID.header())
+	}
+	if id == "" {
+		id = s.Ctx.GetRespHeader(s.RequestID.header())
+	}
+	if id != "" {
+		s.Ctx.Set(s.RequestID.header(), id)
+	}
+	return id
+}
@@ -0,0 +1,74 @@
+package fibererror_test
+
+import (
+	"encoding/json"
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/requestid"
+	"github.com/prongbang/fibererror"
+	"github.com/prongbang/goerror"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+var requestID = requestid.New(requestid.Config{
+	Generator: func() string { return "req-1" },
+})
+
+func TestRequestID(t *testing.T) {
+	res := fibererror.New(&fibererror.Config{RequestID: &fibererror.RequestID{}})
+
+	resp, data := respond(t, res, goerror.NewNotFound(), nil, requestID)
+	var body map[string]any
+	_ = json.Unmarshal([]byte(data), &body)
+
+	if resp.Header.Get(fiber.HeaderXRequestID) != "req-1" || body["request_id"] != "req-1" || body["code"] != goerror.CodeNotFound {
+		t.Error("Error", resp.Header, body)
+	}
+}
+
+func TestRequestIDHeader(t *testing.T) {
+	res := fibererror.New(&fibererror.Config{
+		Format: fibererror.FormatProblem,
+		RequestID: &fibererror.RequestID{
+			Header: "X-Correlation-ID",
+			Field:  "correlation_id",
+		},
+	})
+
+	req := httptest.NewRequest("GET", "/test", nil)
+	req.Header.Set("X-Correlation-ID", "corr-1")
+	resp, data := respond(t, res, goerror.NewNotFound(), req)
+	var body map[string]any
+	_ = json.Unmarshal([]byte(data), &body)
+
+	if resp.Header.Get("X-Correlation-ID") != "corr-1" || body["correlation_id"] != "corr-1" {
+		t.Error("Error", resp.Header, body)
+	}
+}
+
+func TestRequestIDCustom(t *testing.T) {
+	customResp := NewCustomResponse()
+	res := fibererror.New(&fibererror.Config{
+		Custom:    &customResp,
+		RequestID: &fibererror.RequestID{},
+	})
+
+	resp, data := respond(t, res, NewCustomError(), nil, requestID)
+	var body map[string]any
+	_ = json.Unmarshal([]byte(data), &body)
+
+	if resp.StatusCode != http.StatusBadRequest || body["request_id"] != "req-1" || body["code"] != "CUS001" {
+		t.Error("Error", resp.StatusCode, body)
+	}
+}
+
+func TestRequestIDDisabled(t *testing.T) {
+	_, data := respond(t, fibererror.New(&fibererror.Config{}), goerror.NewNotFound(), nil, requestID)
+	var body map[string]any
+	_ = json.Unmarshal([]byte(data), &body)
+
+	if _, ok := body["request_id"]; ok {
+		t.Error("Error", body)
+	}
+}
@@ -1,21 +1,58 @@
 package fibererror
 
 import (
+	"errors"
 	"github.com/gofiber/fiber/v2"
 	"github.com/prongbang/goerror"
-	"net/http"
+	"reflect"
 )
 
 type Config struct {
-	Custom *Custom
-	I18n   *I18n
+	Custom    *Custom
+	Customs   []Custom
+	I18n      *I18n
+	Registry  Registry
+	Format    Format
+	Problem   *Problem
+	Renderers []Renderer
+	Fallback  *Fallback
+	Panic     *Panic
+	OnError   Hook
+	Debug     bool
+	Redact    *Redact
+	RequestID *RequestID
+	Challenge *Challenge
+	Redirect  *Redirect
+}
+
+// Fallback configures the response for an error that neither the Registry
+// nor a Custom handler handles. The zero value writes 500 Internal Server
+// Error.
+type Fallback struct {
+	// Status defaults to 500 Internal Server Error.
+	Status int
+	// Code and Message default to those of the goerror type of Status.
+	Code    string
+	Message string
+	// Response, when set, writes the response instead.
+	Response func(ctx *fiber.Ctx, err error) error
+	// Context writes context.DeadlineExceeded as 504 Gateway Timeout and
+	// context.Canceled as 499 Client Closed Request.
+	Context bool
 }
 
 type I18n struct {
 	Enabled  bool
 	Localize func(c *fiber.Ctx, code string) (string, error)
+	// LocalizeData is used instead of Localize when set, and also receives
+	// the template data of the error, see WithTemplateData.
+	LocalizeData func(c *fiber.Ctx, code string, data map[string]any) (string, error)
 }
 
+// ErrUnhandled is returned by a Custom handler that does not handle err, so
+// the Fallback response is written instead.
+var ErrUnhandled = errors.New("fibererror: unhandled error")
+
 type Custom interface {
 	Response(ctx *fiber.Ctx, err error) error
 }
@@ -29,186 +66,336 @@ type HttpResponse interface {
 }
 
 type response struct {
-	Cus  *Custom
-	I18n *I18n
+	Cus       *Custom
+	Customs   []Custom
+	I18n      *I18n
+	Reg       Registry
+	Format    Format
+	Problem   *Problem
+	Renderers []Renderer
+	Fallback  *Fallback
+	OnError   Hook
+	Debug     bool
+	Redact    *Redact
+	RequestID *RequestID
+	Challenge *Challenge
+	Redirect  *Redirect
 }
 
 type httpResponse struct {
-	Ctx  *fiber.Ctx
-	Cus  *Custom
-	I18n *I18n
+	*response
+	Ctx *fiber.Ctx
+	id  string
 }
 
 // With implements Response.
 func (r *response) With(c *fiber.Ctx) HttpResponse {
 	return &httpResponse{
-		Ctx:  c,
-		Cus:  r.Cus,
-		I18n: r.I18n,
+		response: r,
+		Ctx:      c,
 	}
 }
 
 // Response implements Response.
+//
+// Wrapped errors are unwrapped first, so the response is written for the
+// first error in the chain that is known to the Registry or, failing that,
+// carries a goerror.Body. The caller still owns err and can log the full
+// wrapped message.
+//
+// The chain of err can carry more about the response, attached by the With
+// functions of this package or by errors implementing:
+//
+//	TemplateData() map[string]any  // template data of the message, see WithTemplateData
+//	Localize(LocalizeFunc) error   // localizes the other messages of the error
+//	RetryAfter() time.Duration     // Retry-After header, see WithRetryAfter
+//	RetryAt() time.Time            // Retry-After header, see WithRetryAt
+//	Challenge() Challenge          // WWW-Authenticate or Proxy-Authenticate header, see WithChallenge
+//	Location() string              // Location header, see WithLocation
+//	Allow() []string               // Allow header, see WithAllow
 func (s *httpResponse) Response(err error) error {
-	switch e := err.(type) {
-	// Information
-	case *goerror.Continue:
-		return s.Ctx.Status(http.StatusContinue).JSON(e)
-	case *goerror.SwitchingProtocols:
-		return s.Ctx.Status(http.StatusSwitchingProtocols).JSON(e)
-	case *goerror.Processing:
-		return s.Ctx.Status(http.StatusProcessing).JSON(e)
-	case *goerror.EarlyHints:
-		return s.Ctx.Status(http.StatusEarlyHints).JSON(e)
-
-	// Successful
-	case *goerror.OK:
-		return s.Ctx.Status(http.StatusOK).JSON(e)
-	case *goerror.Created:
-		return s.Ctx.Status(http.StatusCreated).JSON(e)
-	case *goerror.Accepted:
-		return s.Ctx.Status(http.StatusAccepted).JSON(e)
-	case *goerror.NonAuthoritativeInformation:
-		return s.Ctx.Status(http.StatusNonAuthoritativeInfo).JSON(e)
-	case *goerror.NoContent:
-		return s.Ctx.Status(http.StatusNoContent).JSON(e)
-	case *goerror.ResetContent:
-		return s.Ctx.Status(http.StatusResetContent).JSON(e)
-	case *goerror.PartialContent:
-		return s.Ctx.Status(http.StatusPartialContent).JSON(e)
-	case *goerror.MultiStatus:
-		return s.Ctx.Status(http.StatusMultiStatus).JSON(e)
-	case *goerror.AlreadyReported:
-		return s.Ctx.Status(http.StatusAlreadyReported).JSON(e)
-	case *goerror.IMUsed:
-		return s.Ctx.Status(http.StatusIMUsed).JSON(e)
-
-	// Redirection
-	case *goerror.MultipleChoices:
-		return s.Ctx.Status(http.StatusMultipleChoices).JSON(e)
-	case *goerror.MovedPermanently:
-		return s.Ctx.Status(http.StatusMovedPermanently).JSON(e)
-	case *goerror.Found:
-		return s.Ctx.Status(http.StatusFound).JSON(e)
-	case *goerror.SeeOther:
-		return s.Ctx.Status(http.StatusSeeOther).JSON(e)
-	case *goerror.NotModified:
-		return s.Ctx.Status(http.StatusNotModified).JSON(e)
-	case *goerror.UseProxy:
-		return s.Ctx.Status(http.StatusUseProxy).JSON(e)
-	case *goerror.TemporaryRedirect:
-		return s.Ctx.Status(http.StatusTemporaryRedirect).JSON(e)
-	case *goerror.PermanentRedirect:
-		return s.Ctx.Status(http.StatusPermanentRedirect).JSON(e)
-
-	// Client error
-	case *goerror.BadRequest:
-		return s.Ctx.Status(http.StatusBadRequest).JSON(e)
-	case *goerror.Unauthorized:
-		return s.Ctx.Status(http.StatusUnauthorized).JSON(e)
-	case *goerror.PaymentRequired:
-		return s.Ctx.Status(http.StatusPaymentRequired).JSON(e)
-	case *goerror.Forbidden:
-		return s.Ctx.Status(http.StatusForbidden).JSON(e)
-	case *goerror.NotFound:
-		return s.Ctx.Status(http.StatusNotFound).JSON(e)
-	case *goerror.MethodNotAllowed:
-		return s.Ctx.Status(http.StatusMethodNotAllowed).JSON(e)
-	case *goerror.NotAcceptable:
-		return s.Ctx.Status(http.StatusNotAcceptable).JSON(e)
-	case *goerror.ProxyAuthRequired:
-		return s.Ctx.Status(http.StatusProxyAuthRequired).JSON(e)
-	case *goerror.RequestTimeout:
-		return s.Ctx.Status(http.StatusRequestTimeout).JSON(e)
-	case *goerror.Conflict:
-		return s.Ctx.Status(http.StatusConflict).JSON(e)
-	case *goerror.Gone:
-		return s.Ctx.Status(http.StatusGone).JSON(e)
-	case *goerror.LengthRequired:
-		return s.Ctx.Status(http.StatusLengthRequired).JSON(e)
-	case *goerror.PreconditionFailed:
-		return s.Ctx.Status(http.StatusPreconditionFailed).JSON(e)
-	case *goerror.RequestEntityTooLarge:
-		return s.Ctx.Status(http.StatusRequestEntityTooLarge).JSON(e)
-	case *goerror.RequestURITooLong:
-		return s.Ctx.Status(http.StatusRequestURITooLong).JSON(e)
-	case *goerror.UnsupportedMediaType:
-		return s.Ctx.Status(http.StatusUnsupportedMediaType).JSON(e)
-	case *goerror.RequestedRangeNotSatisfiable:
-		return s.Ctx.Status(http.StatusRequestedRangeNotSatisfiable).JSON(e)
-	case *goerror.ExpectationFailed:
-		return s.Ctx.Status(http.StatusExpectationFailed).JSON(e)
-	case *goerror.Teapot:
-		return s.Ctx.Status(http.StatusTeapot).JSON(e)
-	case *goerror.MisdirectedRequest:
-		return s.Ctx.Status(http.StatusMisdirectedRequest).JSON(e)
-	case *goerror.UnprocessableEntity:
-		return s.Ctx.Status(http.StatusUnprocessableEntity).JSON(e)
-	case *goerror.Locked:
-		return s.Ctx.Status(http.StatusLocked).JSON(e)
-	case *goerror.FailedDependency:
-		return s.Ctx.Status(http.StatusFailedDependency).JSON(e)
-	case *goerror.TooEarly:
-		return s.Ctx.Status(http.StatusTooEarly).JSON(e)
-	case *goerror.UpgradeRequired:
-		return s.Ctx.Status(http.StatusUpgradeRequired).JSON(e)
-	case *goerror.PreconditionRequired:
-		return s.Ctx.Status(http.StatusPreconditionRequired).JSON(e)
-	case *goerror.TooManyRequests:
-		return s.Ctx.Status(http.StatusTooManyRequests).JSON(e)
-	case *goerror.RequestHeaderFieldsTooLarge:
-		return s.Ctx.Status(http.StatusRequestHeaderFieldsTooLarge).JSON(e)
-	case *goerror.UnavailableForLegalReasons:
-		return s.Ctx.Status(http.StatusUnavailableForLegalReasons).JSON(e)
-
-	// Server error
-	case *goerror.InternalServerError:
-		return s.Ctx.Status(http.StatusInternalServerError).JSON(e)
-	case *goerror.NotImplemented:
-		return s.Ctx.Status(http.StatusNotImplemented).JSON(e)
-	case *goerror.BadGateway:
-		return s.Ctx.Status(http.StatusBadGateway).JSON(e)
-	case *goerror.ServiceUnavailable:
-		return s.Ctx.Status(http.StatusServiceUnavailable).JSON(e)
-	case *goerror.GatewayTimeout:
-		return s.Ctx.Status(http.StatusGatewayTimeout).JSON(e)
-	case *goerror.HTTPVersionNotSupported:
-		return s.Ctx.Status(http.StatusHTTPVersionNotSupported).JSON(e)
-	case *goerror.VariantAlsoNegotiates:
-		return s.Ctx.Status(http.StatusVariantAlsoNegotiates).JSON(e)
-	case *goerror.InsufficientStorage:
-		return s.Ctx.Status(http.StatusInsufficientStorage).JSON(e)
-	case *goerror.LoopDetected:
-		return s.Ctx.Status(http.StatusLoopDetected).JSON(e)
-	case *goerror.NotExtended:
-		return s.Ctx.Status(http.StatusNotExtended).JSON(e)
-	case *goerror.NetworkAuthenticationRequired:
-		return s.Ctx.Status(http.StatusNetworkAuthenticationRequired).JSON(e)
+	s.id = s.requestID()
+	target, e := s.dispatch(err)
+	if s.OnError != nil {
+		s.OnError(s.Ctx, &Event{
+			Status: s.Ctx.Response().StatusCode(),
+			Code:   bodyOf(target).Code,
+			Err:    err,
+			Target: target,
+		})
+	}
+	return e
+}
+
+// dispatch writes the response for err and returns the error in its chain,
+// or its translation, the response was written for.
+func (s *httpResponse) dispatch(err error) (error, error) {
+	var (
+		target  error
+		mapping Mapping
+	)
+	if walk(err, func(e error) bool {
+		if te := s.Reg.Translate(e); te != nil {
+			e = te
+		}
+		m, ok := s.Reg.Lookup(e)
+		if ok {
+			target, mapping = e, m
+		} else if fe, isFiber := e.(*fiber.Error); isFiber {
+			target, mapping = s.fiberError(fe)
+			ok = true
+		}
+		return ok
+	}) {
+		return target, s.write(mapping, target, err)
+	}
 
 	// Other
-	default:
-		if s.Cus != nil {
-			if s.I18n != nil && s.I18n.Enabled && s.I18n.Localize != nil {
-				body, e1 := goerror.GetBody(err)
-				if e1 == nil && body.Code != "" && body.Message == "" {
-					if localize, e2 := s.I18n.Localize(s.Ctx, body.Code); e2 == nil {
-						goerror.SetMessage(err, localize)
-					}
-				}
+	target = unwrap(err)
+	for _, c := range s.customs() {
+		if handled, e := s.custom(c, target, err); handled {
+			if e == nil {
+				s.extend(err)
+			}
+			return target, e
+		}
+	}
+
+	// Default response
+	return s.fallback(err)
+}
+
+// custom calls the Custom handler and reports whether it handled target. A
+// handler declines target by returning ErrUnhandled, or nil without writing
+// a response, so an unhandled error is never answered with 200 OK.
+//
+// The status is only known once the handler wrote it, so the handler gets a
+// scrubbed message, and when Redact replaces the message of that status the
+// message member of its JSON body is rewritten.
+func (s *httpResponse) custom(c Custom, target error, err error) (bool, error) {
+	res := s.Ctx.Response()
+	status, size := res.StatusCode(), len(res.Body())
+	target = s.localize(target, err)
+	body, scrubbed := bodyOf(target), target
+	if s.Redact != nil {
+		if message := s.redactMessage(0, body, err); message != body.Message {
+			scrubbed = withMessage(target, message)
+		}
+	}
+	e := c.Response(s.Ctx, scrubbed)
+	if errors.Is(e, ErrUnhandled) {
+		return false, nil
+	}
+	if e == nil && res.StatusCode() == status && len(res.Body()) == size {
+		return false, nil
+	}
+	if e == nil && s.Redact != nil {
+		message := bodyOf(scrubbed).Message
+		if s.redacted(res.StatusCode(), body.Code) {
+			message = s.redactMessage(res.StatusCode(), body, err)
+			s.rewrite(message)
+		}
+		if message != body.Message {
+			s.onRedact(body.Message, err)
+		}
+	}
+	return true, e
+}
+
+// fiberError translates e into the goerror type of its code, keeping Fiber's
+// message. Codes without a goerror type are written as a fallbackError, so
+// the body keeps the string code of every other error.
+func (s *httpResponse) fiberError(e *fiber.Error) (error, Mapping) {
+	if ge := statusError(e.Code); ge != nil {
+		if m, ok := s.Reg.Lookup(ge); ok {
+			if e.Message != "" {
+				goerror.SetMessage(ge, e.Message)
+			}
+			return ge, m
+		}
+	}
+	return newFallbackError(e.Code, e.Message), Mapping{Status: e.Code}
+}
+
+// write writes target with the mapping. err is the error given to Response,
+// whose chain may carry more information about target.
+func (s *httpResponse) write(m Mapping, target error, err error) error {
+	target = s.redact(m.Status, s.localize(target, err), err)
+	s.headers(m.Status, err)
+	s.allow(m.Status, err)
+	redirect := s.redirect(m.Status, err)
+	if s.bodiless(m.Status) {
+		s.strip(m.Status)
+		return nil
+	}
+	if m.Render != nil {
+		return m.Render(s.Ctx, m.Status, target)
+	}
+	if redirect {
+		if ok, e := s.redirectBody(m.Status); ok {
+			return e
+		}
+	}
+	var (
+		body   any = target
+		fields     = s.fields(err)
+	)
+	if s.Format == FormatProblem {
+		p := s.problem(m.Status, target)
+		for _, f := range fields {
+			if p.Extensions == nil {
+				p.Extensions = make(map[string]any, len(fields))
+			}
+			p.Extensions[f.Name] = f.Value
+		}
+		body = p
+	} else if len(fields) > 0 {
+		body = extendedBody{body: target, fields: fields}
+	}
+	return s.renderer().Render(s.Ctx.Status(m.Status), body)
+}
+
+// headers writes the headers derived from status and the chain of err.
+func (s *httpResponse) headers(status int, err error) {
+	if r, ok := retryOf(err); ok {
+		s.Ctx.Set(fiber.HeaderRetryAfter, r.Header)
+	}
+	s.challenge(status, err)
+}
+
+// extend adds the headers and members written by Response to a response
+// written by a Custom handler or Fallback.Response.
+func (s *httpResponse) extend(err error) {
+	s.headers(s.Ctx.Response().StatusCode(), err)
+	s.inject(s.fields(err))
+}
+
+// fields returns the members added to the written body.
+func (s *httpResponse) fields(err error) []field {
+	var fields []field
+	if r, ok := retryOf(err); ok {
+		fields = append(fields, field{Name: "retry_after", Value: r.Seconds})
+	}
+	if s.id != "" {
+		fields = append(fields, field{Name: s.RequestID.field(), Value: s.id})
+	}
+	if s.Debug {
+		fields = append(fields, field{Name: "debug", Value: debugOf(err)})
+	}
+	return fields
+}
+
+// unwrap returns the first error in the tree of err that carries a
+// goerror.Body, or err itself when there is none.
+func unwrap(err error) error {
+	target := err
+	walk(err, func(e error) bool {
+		if _, ok := getBody(e); ok {
+			target = e
+			return true
+		}
+		return false
+	})
+	return target
+}
+
+var bodyType = reflect.TypeOf(goerror.Body{})
+
+// getBody returns the goerror.Body of err. Errors with a Body field of
+// another type, such as the string Body of HTTP client errors, have none,
+// as goerror.GetBody panics on them.
+func getBody(err error) (goerror.Body, bool) {
+	v := reflect.ValueOf(err)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return goerror.Body{}, false
+	}
+	if f, ok := v.Elem().Type().FieldByName("Body"); !ok || f.Type != bodyType {
+		return goerror.Body{}, false
+	}
+	body, e := goerror.GetBody(err)
+	return body, e == nil
+}
+
+// annotation attaches a value to an error, see WithTemplateData.
+type annotation struct {
+	error
+	value any
+}
+
+// Unwrap returns the wrapped error.
+func (a *annotation) Unwrap() error {
+	return a.error
+}
+
+func annotate(err error, value any) error {
+	return &annotation{error: err, value: value}
+}
+
+// valueOf returns the value attached to e, or e itself.
+func valueOf(e error) any {
+	if a, ok := e.(*annotation); ok {
+		return a.value
+	}
+	return e
+}
+
+// lookup returns the first value in the chain of err that get accepts. get
+// receives the values attached to the errors, or else the errors themselves.
+func lookup[T any](err error, get func(v any) (T, bool)) (T, bool) {
+	var (
+		value T
+		found bool
+	)
+	walk(err, func(e error) bool {
+		value, found = get(valueOf(e))
+		return found
+	})
+	return value, found
+}
+
+// walk visits err and its wrapped errors depth-first, following both
+// Unwrap() error and Unwrap() []error, until fn returns true.
+func walk(err error, fn func(err error) bool) bool {
+	if err == nil {
+		return false
+	}
+	if fn(err) {
+		return true
+	}
+	switch u := err.(type) {
+	case interface{ Unwrap() error }:
+		return walk(u.Unwrap(), fn)
+	case interface{ Unwrap() []error }:
+		for _, e := range u.Unwrap() {
+			if walk(e, fn) {
+				return true
 			}
-			return (*s.Cus).Response(s.Ctx, err)
 		}
-		// Default response
-		return s.Ctx.Status(http.StatusBadRequest).JSON(goerror.NewBadRequest())
 	}
+	return false
 }
 
 func New(config ...*Config) Response {
 	resp := &response{}
-	if len(config) > 0 {
+	if len(config) > 0 && config[0] != nil {
 		cfg := config[0]
 		resp.Cus = cfg.Custom
+		resp.Customs = cfg.Customs
 		resp.I18n = cfg.I18n
+		resp.Reg = cfg.Registry
+		resp.Format = cfg.Format
+		resp.Problem = cfg.Problem
+		resp.Renderers = cfg.Renderers
+		resp.Fallback = cfg.Fallback
+		resp.OnError = cfg.OnError
+		resp.Debug = cfg.Debug
+		resp.Redact = cfg.Redact
+		resp.RequestID = cfg.RequestID
+		resp.Challenge = cfg.Challenge
+		resp.Redirect = cfg.Redirect
+	}
+	if resp.Reg == nil {
+		resp.Reg = NewRegistry()
 	}
 	return resp
 }
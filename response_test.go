@@ -1,16 +1,43 @@
 package fibererror_test
 
 import (
+	"errors"
+	"fmt"
 	"github.com/gofiber/fiber/v2"
 	"github.com/prongbang/fibererror"
 	"github.com/prongbang/goerror"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 )
 
 var response = fibererror.New()
 
+// respond returns the response and body of req, a GET /test when nil,
+// answered with err written by res behind middleware.
+func respond(t *testing.T, res fibererror.Response, err error, req *http.Request, middleware ...fiber.Handler) (*http.Response, string) {
+	t.Helper()
+	if req == nil {
+		req = httptest.NewRequest("GET", "/test", nil)
+	}
+	app := fiber.New()
+	for _, m := range middleware {
+		app.Use(m)
+	}
+	app.Add(req.Method, "/test", func(c *fiber.Ctx) error {
+		return res.With(c).Response(err)
+	})
+
+	resp, e := app.Test(req)
+	if e != nil {
+		t.Fatal("Error", e)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	return resp, string(body)
+}
+
 type CustomError struct {
 	goerror.Body
 }
@@ -63,6 +90,121 @@ func TestNewCustomError(t *testing.T) {
 	}
 }
 
+type declineResponse struct {
+}
+
+// Response implements response.Custom.
+func (d *declineResponse) Response(ctx *fiber.Ctx, err error) error {
+	return fibererror.ErrUnhandled
+}
+
+func TestCustomReturnsNil(t *testing.T) {
+	app := fiber.New()
+
+	customResp := NewCustomResponse()
+	res := fibererror.New(&fibererror.Config{
+		Custom: &customResp,
+	})
+
+	app.Get("/test", func(c *fiber.Ctx) error {
+		return res.With(c).Response(errors.New("unknown"))
+	})
+
+	resp, _ := app.Test(httptest.NewRequest("GET", "/test", nil))
+
+	if resp.StatusCode == http.StatusOK {
+		t.Error("Error", resp.StatusCode)
+	}
+}
+
+func TestCustomUnhandledFallback(t *testing.T) {
+	app := fiber.New()
+
+	var customResp fibererror.Custom = &declineResponse{}
+	res := fibererror.New(&fibererror.Config{
+		Custom: &customResp,
+		Fallback: &fibererror.Fallback{
+			Response: func(ctx *fiber.Ctx, err error) error {
+				return ctx.Status(http.StatusServiceUnavailable).JSON(goerror.NewServiceUnavailable())
+			},
+		},
+	})
+
+	app.Get("/test", func(c *fiber.Ctx) error {
+		return res.With(c).Response(NewCustomError())
+	})
+
+	resp, _ := app.Test(httptest.NewRequest("GET", "/test", nil))
+
+	if resp.StatusCode != http.StatusServiceUnavailable {
+		t.Error("Error", resp.StatusCode)
+	}
+}
+
+func TestWrappedError(t *testing.T) {
+	app := fiber.New()
+	app.Get("/test", func(c *fiber.Ctx) error {
+		return response.With(c).Response(fmt.Errorf("load user: %w", goerror.NewNotFound()))
+	})
+
+	resp, _ := app.Test(httptest.NewRequest("GET", "/test", nil))
+
+	if resp.StatusCode != http.StatusNotFound {
+		t.Error("Error", resp.StatusCode)
+	}
+}
+
+func TestJoinedError(t *testing.T) {
+	app := fiber.New()
+	app.Get("/test", func(c *fiber.Ctx) error {
+		return response.With(c).Response(errors.Join(errors.New("db"), goerror.NewConflict()))
+	})
+
+	resp, _ := app.Test(httptest.NewRequest("GET", "/test", nil))
+
+	if resp.StatusCode != http.StatusConflict {
+		t.Error("Error", resp.StatusCode)
+	}
+}
+
+func TestWrappedCustomError(t *testing.T) {
+	app := fiber.New()
+
+	customResp := NewCustomResponse()
+	res := fibererror.New(&fibererror.Config{
+		Custom: &customResp,
+	})
+
+	app.Get("/test", func(c *fiber.Ctx) error {
+		return res.With(c).Response(fmt.Errorf("handler: %w", NewCustomError()))
+	})
+
+	resp, _ := app.Test(httptest.NewRequest("GET", "/test", nil))
+	body, _ := io.ReadAll(resp.Body)
+
+	if resp.StatusCode != http.StatusBadRequest || !strings.Contains(string(body), "CUS001") {
+		t.Error("Error", resp.StatusCode, string(body))
+	}
+}
+
+type apiError struct {
+	Code int
+	Body string
+}
+
+// Error implements error.
+func (a *apiError) Error() string {
+	return a.Body
+}
+
+func TestWrappedStringBodyError(t *testing.T) {
+	resp, body := respond(t, response, fmt.Errorf("call: %w", &apiError{Code: 500, Body: "x"}), nil)
+
+	if resp.StatusCode != http.StatusInternalServerError || !strings.Contains(body, goerror.CodeInternalServerError) {
+		t.Error("Error", resp.StatusCode, body)
+	}
+}
+
 func TestNewUseProxy(t *testing.T) {
 	app := fiber.New()
 	app.Get("/test", func(c *fiber.Ctx) error {
@@ -0,0 +1,73 @@
+package fibererror
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+type (
+	retryAfter time.Duration
+	retryAt    time.Time
+)
+
+// WithRetryAfter wraps err with the delay after which the request can be
+// retried, written as a Retry-After header in seconds and a retry_after body
+// member:
+//
+//	response.With(c).Response(fibererror.WithRetryAfter(goerror.NewTooManyRequests(), 30*time.Second))
+//	// Retry-After: 30
+//	// 429 {"code":"CLE026","message":"Too Many Requests","data":null,"retry_after":30}
+func WithRetryAfter(err error, after time.Duration) error {
+	return annotate(err, retryAfter(after))
+}
+
+// WithRetryAt wraps err with the time from which the request can be retried,
+// written as a Retry-After HTTP-date and a retry_after member in seconds.
+func WithRetryAt(err error, at time.Time) error {
+	return annotate(err, retryAt(at))
+}
+
+// retry is the Retry-After of an error.
+type retry struct {
+	Header  string
+	Seconds int64
+}
+
+// retryOf returns the Retry-After of the first error in the chain of err
+// that carries one.
+func retryOf(err error) (retry, bool) {
+	return lookup(err, func(v any) (retry, bool) {
+		switch t := v.(type) {
+		case retryAfter:
+			return retryIn(time.Duration(t)), true
+		case retryAt:
+			return retryUntil(time.Time(t)), true
+		case interface{ RetryAfter() time.Duration }:
+			return retryIn(t.RetryAfter()), true
+		case interface{ RetryAt() time.Time }:
+			return retryUntil(t.RetryAt()), true
+		}
+		return retry{}, false
+	})
+}
+
+func retryIn(d time.Duration) retry {
+	r := retry{Seconds: seconds(d)}
+	r.Header = strconv.FormatInt(r.Seconds, 10)
+	return r
+}
+
+func retryUntil(at time.Time) retry {
+	return retry{Header: at.UTC().Format(http.TimeFormat), Seconds: seconds(time.Until(at))}
+}
+
+// seconds returns d in whole seconds, rounded up so a client never retries
+// early, and never negative.
+func seconds(d time.Duration) int64 {
+	if d <= 0 {
+		return 0
+	}
+	return int64(math.Ceil(d.Seconds()))
+}
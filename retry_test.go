@@ -0,0 +1,86 @@
+package fibererror_test
+
+import (
+	"fmt"
+	"github.com/gofiber/fiber/v2"
+	"github.com/prongbang/fibererror"
+	"github.com/prongbang/goerror"
+	"net/http"
+	"testing"
+	"time"
+)
+
+type QuotaError struct {
+	goerror.Body
+}
+
+// Error implements error.
+func (q *QuotaError) Error() string {
+	return q.Message
+}
+
+// RetryAfter implements the retry delay of QuotaError.
+func (q *QuotaError) RetryAfter() time.Duration {
+	return time.Minute
+}
+
+func TestWithRetryAfter(t *testing.T) {
+	err := fmt.Errorf("rate limit: %w", fibererror.WithRetryAfter(goerror.NewTooManyRequests(), 1500*time.Millisecond))
+	resp, body := respond(t, response, err, nil)
+
+	expected := `{"code":"CLE026","message":"Too Many Requests","data":null,"retry_after":2}`
+	if resp.StatusCode != http.StatusTooManyRequests || resp.Header.Get(fiber.HeaderRetryAfter) != "2" || body != expected {
+		t.Error("Error", resp.StatusCode, resp.Header, body)
+	}
+}
+
+func TestWithRetryAt(t *testing.T) {
+	at := time.Now().Add(time.Hour)
+	resp, body := respond(t, response, fibererror.WithRetryAt(goerror.NewServiceUnavailable(), at), nil)
+
+	header := resp.Header.Get(fiber.HeaderRetryAfter)
+	if parsed, e := http.ParseTime(header); e != nil || parsed.Unix() != at.Unix() {
+		t.Error("Error", header)
+	}
+	expected := `{"code":"SVR003","message":"Service Unavailable","data":null,"retry_after":3600}`
+	if resp.StatusCode != http.StatusServiceUnavailable || body != expected {
+		t.Error("Error", resp.StatusCode, body)
+	}
+}
+
+func TestRetryAfterCustomError(t *testing.T) {
+	registry := fibererror.NewRegistry()
+	registry.Register(&QuotaError{}, http.StatusTooManyRequests)
+
+	resp, body := respond(t, fibererror.New(&fibererror.Config{
+		Registry: registry,
+		Format:   fibererror.FormatProblem,
+	}), &QuotaError{Body: goerror.Body{Code: "QUOTA", Message: "Quota exceeded"}}, nil)
+
+	expected := `{"type":"about:blank","title":"Too Many Requests","status":429,"detail":"Quota exceeded","instance":"/test","code":"QUOTA","retry_after":60}`
+	if resp.Header.Get(fiber.HeaderRetryAfter) != "60" || body != expected {
+		t.Error("Error", resp.Header, body)
+	}
+}
+
+func TestRetryAfterNotSet(t *testing.T) {
+	resp, _ := respond(t, response, goerror.NewTooManyRequests(), nil)
+
+	if resp.Header.Get(fiber.HeaderRetryAfter) != "" {
+		t.Error("Error", resp.Header)
+	}
+}
+
+func TestRetryAfterCustom(t *testing.T) {
+	customResp := NewCustomResponse()
+	res := fibererror.New(&fibererror.Config{
+		Custom: &customResp,
+	})
+
+	resp, body := respond(t, res, fibererror.WithRetryAfter(NewCustomError(), 30*time.Second), nil)
+
+	expected := `{"code":"CUS001","message":"","data":null,"retry_after":30}`
+	if resp.StatusCode != http.StatusBadRequest || resp.Header.Get(fiber.HeaderRetryAfter) != "30" || body != expected {
+		t.Error("Error", resp.StatusCode, resp.Header, body)
+	}
+}
@@ -0,0 +1,155 @@
+// Package validate writes go-playground/validator errors through fibererror
+// as 422 Unprocessable Entity with the details of each invalid field.
+package validate
+
+import (
+	"fmt"
+	"github.com/go-playground/validator/v10"
+	"github.com/prongbang/fibererror"
+	"github.com/prongbang/goerror"
+	"net/http"
+	"reflect"
+	"strings"
+)
+
+// KeyPrefix is joined with the rule of a Detail to build its i18n key, e.g.
+// "validation.required".
+const KeyPrefix = "validation."
+
+// Detail is the validation failure of one field.
+type Detail struct {
+	Field   string `json:"field"`
+	Rule    string `json:"rule"`
+	Param   string `json:"param"`
+	Message string `json:"message"`
+}
+
+// Error is the error written for validator.ValidationErrors.
+type Error struct {
+	goerror.Body
+	Details []Detail `json:"details"`
+	errs    validator.ValidationErrors
+}
+
+// Error implements error.
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// Unwrap returns the validation errors.
+func (e *Error) Unwrap() error {
+	return e.errs
+}
+
+// Localize localizes the detail messages by rule, keyed by KeyPrefix and the
+// rule, with the Field and Param template data:
+//
+//	validation:
+//	  required: "{{.Field}} is required"
+//	  min: "{{.Field}} must be at least {{.Param}}"
+func (e *Error) Localize(localize fibererror.LocalizeFunc) error {
+	c := *e
+	c.Details = make([]Detail, len(e.Details))
+	for i, d := range e.Details {
+		c.Details[i] = d
+		data := map[string]any{"Field": d.Field, "Param": d.Param}
+		if message, ok := localize(KeyPrefix+d.Rule, data); ok {
+			c.Details[i].Message = message
+		}
+	}
+	return &c
+}
+
+// NewError returns the Error for errs.
+func NewError(errs validator.ValidationErrors) *Error {
+	e := &Error{
+		Body: goerror.Body{
+			Code:    goerror.CodeUnprocessableEntity,
+			Message: http.StatusText(http.StatusUnprocessableEntity),
+		},
+		Details: make([]Detail, len(errs)),
+		errs:    errs,
+	}
+	for i, fe := range errs {
+		e.Details[i] = Detail{
+			Field: field(fe),
+			Rule:  fe.Tag(),
+			Param: fe.Param(),
+		}
+		e.Details[i].Message = message(e.Details[i])
+	}
+	return e
+}
+
+// Translate is a fibererror.Translator for validator.ValidationErrors.
+func Translate(err error) error {
+	if errs, ok := err.(validator.ValidationErrors); ok {
+		return NewError(errs)
+	}
+	return nil
+}
+
+// Register maps Error to 422 Unprocessable Entity in the registry and
+// translates validator.ValidationErrors into it:
+//
+//	registry := fibererror.NewRegistry()
+//	validate.Register(registry)
+func Register(registry fibererror.Registry) {
+	registry.Register(&Error{}, http.StatusUnprocessableEntity)
+	registry.RegisterTranslator(Translate)
+}
+
+// New returns a validator that reports fields by their JSON names.
+func New() *validator.Validate {
+	v := validator.New()
+	v.RegisterTagNameFunc(JSONTagName)
+	return v
+}
+
+// JSONTagName returns the JSON name of a struct field, to be registered with
+// validator.Validate.RegisterTagNameFunc.
+func JSONTagName(fld reflect.StructField) string {
+	name, _, _ := strings.Cut(fld.Tag.Get("json"), ",")
+	switch name {
+	case "-":
+		return ""
+	case "":
+		return fld.Name
+	}
+	return name
+}
+
+// field returns the path of the field without the name of the validated
+// struct, e.g. "address.city".
+func field(fe validator.FieldError) string {
+	if _, path, ok := strings.Cut(fe.Namespace(), "."); ok {
+		return path
+	}
+	return fe.Field()
+}
+
+// messages are the default messages by rule.
+var messages = map[string]string{
+	"required": "%[1]s is required",
+	"email":    "%[1]s must be a valid email address",
+	"url":      "%[1]s must be a valid URL",
+	"uuid":     "%[1]s must be a valid UUID",
+	"numeric":  "%[1]s must be numeric",
+	"alpha":    "%[1]s must contain only letters",
+	"alphanum": "%[1]s must contain only letters and numbers",
+	"len":      "%[1]s must have a length of %[2]s",
+	"min":      "%[1]s must be at least %[2]s",
+	"max":      "%[1]s must be at most %[2]s",
+	"gt":       "%[1]s must be greater than %[2]s",
+	"gte":      "%[1]s must be greater than or equal to %[2]s",
+	"lt":       "%[1]s must be less than %[2]s",
+	"lte":      "%[1]s must be less than or equal to %[2]s",
+	"oneof":    "%[1]s must be one of [%[2]s]",
+}
+
+func message(d Detail) string {
+	if format, ok := messages[d.Rule]; ok {
+		return fmt.Sprintf(format, d.Field, d.Param)
+	}
+	return fmt.Sprintf("%s failed on the '%s' rule", d.Field, d.Rule)
+}
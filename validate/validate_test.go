@@ -0,0 +1,88 @@
+package validate_test
+
+import (
+	"fmt"
+	"github.com/gofiber/fiber/v2"
+	"github.com/prongbang/fibererror"
+	"github.com/prongbang/fibererror/validate"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type Address struct {
+	City string `json:"city" validate:"required"`
+}
+
+type User struct {
+	Email   string  `json:"email" validate:"required,email"`
+	Age     int     `json:"age" validate:"gte=18"`
+	Address Address `json:"address"`
+}
+
+func TestValidationErrors(t *testing.T) {
+	registry := fibererror.NewRegistry()
+	validate.Register(registry)
+	res := fibererror.New(&fibererror.Config{Registry: registry})
+
+	v := validate.New()
+	app := fiber.New()
+	app.Get("/test", func(c *fiber.Ctx) error {
+		if err := v.Struct(User{Email: "john", Age: 17}); err != nil {
+			return res.With(c).Response(fmt.Errorf("create user: %w", err))
+		}
+		return c.SendStatus(fiber.StatusNoContent)
+	})
+
+	resp, _ := app.Test(httptest.NewRequest("GET", "/test", nil))
+	body, _ := io.ReadAll(resp.Body)
+
+	expected := `{"code":"CLE020","message":"Unprocessable Entity","data":null,"details":[` +
+		`{"field":"email","rule":"email","param":"","message":"email must be a valid email address"},` +
+		`{"field":"age","rule":"gte","param":"18","message":"age must be greater than or equal to 18"},` +
+		`{"field":"address.city","rule":"required","param":"","message":"address.city is required"}]}`
+	if resp.StatusCode != fiber.StatusUnprocessableEntity || string(body) != expected {
+		t.Error("Error", resp.StatusCode, string(body))
+	}
+}
+
+func TestValidationErrorsLocalized(t *testing.T) {
+	messages := map[string]string{
+		"CLE020":              "ข้อมูลไม่ถูกต้อง",
+		"validation.required": "กรุณาระบุ {{.Field}}",
+	}
+	registry := fibererror.NewRegistry()
+	validate.Register(registry)
+	res := fibererror.New(&fibererror.Config{
+		Registry: registry,
+		I18n: &fibererror.I18n{
+			Enabled: true,
+			LocalizeData: func(c *fiber.Ctx, code string, data map[string]any) (string, error) {
+				message, ok := messages[code]
+				if !ok {
+					return "", fmt.Errorf("message %s not found", code)
+				}
+				return strings.ReplaceAll(message, "{{.Field}}", fmt.Sprint(data["Field"])), nil
+			},
+		},
+	})
+
+	v := validate.New()
+	app := fiber.New()
+	app.Get("/test", func(c *fiber.Ctx) error {
+		if err := v.Struct(User{Email: "john@example.com", Age: 18}); err != nil {
+			return res.With(c).Response(fmt.Errorf("create user: %w", err))
+		}
+		return c.SendStatus(fiber.StatusNoContent)
+	})
+
+	resp, _ := app.Test(httptest.NewRequest("GET", "/test", nil))
+	body, _ := io.ReadAll(resp.Body)
+
+	expected := `{"code":"CLE020","message":"ข้อมูลไม่ถูกต้อง","data":null,"details":[` +
+		`{"field":"address.city","rule":"required","param":"","message":"กรุณาระบุ address.city"}]}`
+	if resp.StatusCode != fiber.StatusUnprocessableEntity || string(body) != expected {
+		t.Error("Error", resp.StatusCode, string(body))
+	}
+}